@@ -0,0 +1,419 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+const (
+	RuleMissingAt           = "missing_at"
+	RuleEmptyLocalPart      = "empty_local_part"
+	RuleLocalPartTooLong    = "local_part_too_long"
+	RuleAddressTooLong      = "address_too_long"
+	RuleEmptyDomain         = "empty_domain"
+	RuleInvalidDomain       = "invalid_domain"
+	RuleDomainLabelTooLong  = "domain_label_too_long"
+	RuleInvalidIPLiteral    = "invalid_ip_literal"
+	RuleInvalidIDN          = "invalid_idn"
+	RuleSingleLabelDomain   = "single_label_domain"
+	RuleInvalidLocalPart    = "invalid_local_part"
+	RuleLeadingDot          = "leading_dot"
+	RuleTrailingDot         = "trailing_dot"
+	RuleConsecutiveDots     = "consecutive_dots"
+	RuleInvalidQuotedString = "invalid_quoted_string"
+	RuleUnterminatedQuote   = "unterminated_quoted_string"
+	RuleUnterminatedComment = "unterminated_comment"
+	MaxLocalPartLength      = 64
+	MaxEmailAddressLength   = 254
+	MaxDomainLabelLength    = 63
+)
+
+// atextSpecials - Characters other than letters and digits allowed in atoms
+const atextSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+// aceLabelPrefix - Prefix of IDNA A-labels (punycode encoded labels)
+const aceLabelPrefix = "xn--"
+
+// ipv6LiteralTag - Prefix of IPv6 address literals, matched case insensitively
+const ipv6LiteralTag = "ipv6:"
+
+// commentWhitespace - Whitespace allowed next to comments
+const commentWhitespace = " \t"
+
+// AddressOptions - Syntax strict parsing does not accept unless asked to
+type AddressOptions struct {
+	// SMTPUTF8 - Accept UTF-8 characters in local part (RFC 6531)
+	SMTPUTF8 bool
+
+	// SingleLabelDomains - Accept host names made of a single label, such as
+	// localhost
+	SingleLabelDomains bool
+}
+
+// Address - Email address (RFC 5322 addr-spec) split into local part and
+// domain with comments stripped off. Local part keeps its quotes, domain of an
+// IP literal keeps its brackets.
+type Address struct {
+	LocalPart string
+	Domain    string
+	Comments  []string
+}
+
+// String - Will return address without comments
+func (a *Address) String() string {
+	return a.LocalPart + "@" + a.Domain
+}
+
+// Quoted - Will check if local part is a quoted string
+func (a *Address) Quoted() bool {
+	return strings.HasPrefix(a.LocalPart, `"`)
+}
+
+// IPLiteral - Will check if domain is an IP address literal
+func (a *Address) IPLiteral() bool {
+	return strings.HasPrefix(a.Domain, "[")
+}
+
+// AddressError - Rule address failed to parse on. Rule is one of Rule*
+// constants.
+type AddressError struct {
+	Rule    string
+	Address string
+}
+
+// Error - Will describe which rule address breaks
+func (e *AddressError) Error() string {
+	return fmt.Sprintf("invalid (address: %q) breaking (rule: %s)", e.Address, e.Rule)
+}
+
+// ParseAddress - Will parse email address according to RFC 5322 addr-spec
+// with RFC 5321 limits applied. Local part is either a dot-atom or a quoted
+// string of ASCII characters, domain is either a host name or an IPv4 / IPv6
+// literal. Host names have at least two labels and may be internationalized
+// (IDNA). Comments are allowed at start and end of local part and domain,
+// whitespace only next to them. Obsolete syntax is not supported.
+func ParseAddress(email string) (*Address, error) {
+	return parseAddress(email, AddressOptions{})
+}
+
+// ParseInternationalAddress - Will parse email address same as ParseAddress
+// while also accepting UTF-8 characters in local part as RFC 6531 (SMTPUTF8)
+// allows.
+func ParseInternationalAddress(email string) (*Address, error) {
+	return parseAddress(email, AddressOptions{SMTPUTF8: true})
+}
+
+// parseAddress - Will parse email address accepting syntax options allow
+func parseAddress(email string, opts AddressOptions) (*Address, error) {
+	fail := func(rule string) (*Address, error) {
+		return nil, &AddressError{Rule: rule, Address: email}
+	}
+
+	at, rule := addressSeparator(email)
+	if rule != "" {
+		return fail(rule)
+	}
+
+	local, localComments, localOK := stripComments(email[:at])
+	domain, domainComments, domainOK := stripComments(email[at+1:])
+
+	switch {
+	case local == "":
+		return fail(RuleEmptyLocalPart)
+	case len(local) > MaxLocalPartLength:
+		return fail(RuleLocalPartTooLong)
+	case len(local)+1+len(domain) > MaxEmailAddressLength:
+		return fail(RuleAddressTooLong)
+	case domain == "":
+		return fail(RuleEmptyDomain)
+	case !domainOK:
+		return fail(RuleInvalidDomain)
+	}
+
+	if rule := domainSyntaxRule(domain, opts.SingleLabelDomains); rule != "" {
+		return fail(rule)
+	}
+
+	if !localOK {
+		return fail(RuleInvalidLocalPart)
+	}
+
+	if rule := localPartRule(local, opts.SMTPUTF8); rule != "" {
+		return fail(rule)
+	}
+
+	return &Address{
+		LocalPart: local,
+		Domain:    domain,
+		Comments:  append(localComments, domainComments...),
+	}, nil
+}
+
+// addressSeparator - Will return position of the @ separating local part and
+// domain, that is the last one outside of quoted strings and comments.
+func addressSeparator(email string) (int, string) {
+	at, depth, quoted := -1, 0, false
+
+	for i := 0; i < len(email); i++ {
+		switch c := email[i]; {
+		case c == '\\' && (quoted || depth > 0):
+			i++
+		case c == '"' && depth == 0:
+			quoted = !quoted
+		case c == '(' && !quoted:
+			depth++
+		case c == ')' && !quoted && depth > 0:
+			depth--
+		case c == '@' && !quoted && depth == 0:
+			at = i
+		}
+	}
+
+	switch {
+	case quoted:
+		return -1, RuleUnterminatedQuote
+	case depth > 0:
+		return -1, RuleUnterminatedComment
+	case at < 0:
+		return -1, RuleMissingAt
+	}
+
+	return at, ""
+}
+
+// stripComments - Will strip comments off start and end of an address part
+// along with whitespace next to them. False is returned for comments in the
+// middle of the part.
+func stripComments(part string) (string, []string, bool) {
+	var text strings.Builder
+	comments := []string{}
+	positions := []int{}
+	quoted := false
+
+	for i := 0; i < len(part); i++ {
+		switch c := part[i]; {
+		case c == '\\' && quoted && i+1 < len(part):
+			text.WriteString(part[i : i+2])
+			i++
+		case c == '"':
+			quoted = !quoted
+			text.WriteByte(c)
+		case c == '(' && !quoted:
+			end := commentEnd(part, i)
+			comments = append(comments, part[i+1:end])
+			positions = append(positions, text.Len())
+			i = end
+		default:
+			text.WriteByte(c)
+		}
+	}
+
+	s := text.String()
+	leading, trailing := false, false
+
+	for _, pos := range positions {
+		switch {
+		case strings.Trim(s[:pos], commentWhitespace) == "":
+			leading = true
+		case strings.Trim(s[pos:], commentWhitespace) == "":
+			trailing = true
+		default:
+			return s, comments, false
+		}
+	}
+
+	if leading {
+		s = strings.TrimLeft(s, commentWhitespace)
+	}
+
+	if trailing {
+		s = strings.TrimRight(s, commentWhitespace)
+	}
+
+	return s, comments, true
+}
+
+// commentEnd - Will return position of parenthesis closing comment opened at
+// provided position. Comments nest and may escape characters with backslash.
+func commentEnd(s string, start int) int {
+	depth := 0
+
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(s) - 1
+}
+
+// localPartRule - Will return rule provided local part breaks, if any. Local
+// part is either a dot-atom or a quoted string, UTF-8 characters are accepted
+// in both only if asked to.
+func localPartRule(local string, utf8Local bool) string {
+	if utf8Local && !utf8.ValidString(local) {
+		return RuleInvalidLocalPart
+	}
+
+	if strings.HasPrefix(local, `"`) {
+		return quotedStringRule(local, utf8Local)
+	}
+
+	switch {
+	case strings.HasPrefix(local, "."):
+		return RuleLeadingDot
+	case strings.HasSuffix(local, "."):
+		return RuleTrailingDot
+	case strings.Contains(local, ".."):
+		return RuleConsecutiveDots
+	}
+
+	for i := 0; i < len(local); i++ {
+		if c := local[i]; c != '.' && !isAtext(c) && !(utf8Local && c >= utf8.RuneSelf) {
+			return RuleInvalidLocalPart
+		}
+	}
+
+	return ""
+}
+
+// quotedStringRule - Will check that local part is a single quoted string of
+// printable characters, quotes and backslashes escaped.
+func quotedStringRule(local string, utf8Local bool) string {
+	if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+		return RuleInvalidQuotedString
+	}
+
+	for i := 1; i < len(local)-1; i++ {
+		c := local[i]
+
+		switch {
+		case utf8Local && c >= utf8.RuneSelf:
+		case c == '\\' && i+1 < len(local)-1:
+			if i++; local[i] < ' ' || local[i] > '~' {
+				return RuleInvalidQuotedString
+			}
+		case c == '"' || c == '\\' || c < ' ' || c > '~':
+			return RuleInvalidQuotedString
+		}
+	}
+
+	return ""
+}
+
+// isAtext - Will check if provided character may appear in an atom
+func isAtext(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.IndexByte(atextSpecials, c) >= 0
+}
+
+// isASCII - Will check if provided string has nothing but ASCII characters
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// DomainSyntaxRule - Will return rule provided email domain breaks or empty
+// string if it is a valid host name or IP literal. Host names are made of
+// letters, digits and hyphens, labels up to 63 characters long that neither
+// start nor end with a hyphen. Internationalized host names are checked in
+// their A-label form and A-labels have to decode into valid U-labels. Host
+// names have to have at least two labels.
+func DomainSyntaxRule(domain string) string {
+	return domainSyntaxRule(domain, false)
+}
+
+// domainSyntaxRule - Will check domain same as DomainSyntaxRule while
+// accepting single label host names if asked to
+func domainSyntaxRule(domain string, singleLabel bool) string {
+	if domain == "" {
+		return RuleEmptyDomain
+	}
+
+	if strings.HasPrefix(domain, "[") {
+		return ipLiteralRule(domain)
+	}
+
+	if !isASCII(domain) {
+		ascii, err := idna.Lookup.ToASCII(domain)
+		if err != nil {
+			return RuleInvalidIDN
+		}
+		domain = ascii
+	}
+
+	if len(domain) > MaxEmailAddressLength {
+		return RuleInvalidDomain
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 && !singleLabel {
+		return RuleSingleLabelDomain
+	}
+
+	for _, label := range labels {
+		switch {
+		case label == "":
+			return RuleInvalidDomain
+		case len(label) > MaxDomainLabelLength:
+			return RuleDomainLabelTooLong
+		case label[0] == '-' || label[len(label)-1] == '-':
+			return RuleInvalidDomain
+		}
+
+		for i := 0; i < len(label); i++ {
+			if c := label[i]; !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return RuleInvalidDomain
+			}
+		}
+
+		if strings.HasPrefix(strings.ToLower(label), aceLabelPrefix) {
+			if _, err := idna.Lookup.ToUnicode(label); err != nil {
+				return RuleInvalidIDN
+			}
+		}
+	}
+
+	return ""
+}
+
+// ipLiteralRule - Will check that domain literal is "[IPv4]" or "[IPv6:IPv6]"
+func ipLiteralRule(domain string) string {
+	if !strings.HasSuffix(domain, "]") {
+		return RuleInvalidIPLiteral
+	}
+
+	literal := domain[1 : len(domain)-1]
+
+	if strings.HasPrefix(strings.ToLower(literal), ipv6LiteralTag) {
+		if ip := net.ParseIP(literal[len(ipv6LiteralTag):]); ip == nil || !strings.Contains(literal[len(ipv6LiteralTag):], ":") {
+			return RuleInvalidIPLiteral
+		}
+		return ""
+	}
+
+	if ip := net.ParseIP(literal); ip == nil || ip.To4() == nil || strings.Contains(literal, ":") {
+		return RuleInvalidIPLiteral
+	}
+
+	return ""
+}
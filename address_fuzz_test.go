@@ -0,0 +1,50 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+//go:build go1.18
+// +build go1.18
+
+package main
+
+import (
+	"testing"
+)
+
+// FuzzParseAddress - Parsed addresses, strict and international alike, have to
+// respect length limits and parse back into themselves once comments are
+// stripped. Failures have to name the rule that broke.
+func FuzzParseAddress(f *testing.F) {
+	for _, tc := range validAddresses {
+		f.Add(tc.email)
+	}
+
+	for _, tc := range invalidAddresses {
+		f.Add(tc.email)
+	}
+
+	f.Fuzz(func(t *testing.T, email string) {
+		for _, parse := range []func(string) (*Address, error){ParseAddress, ParseInternationalAddress} {
+			address, err := parse(email)
+			if err != nil {
+				if err.(*AddressError).Rule == "" {
+					t.Fatalf("no rule reported for (email: %q)", email)
+				}
+				continue
+			}
+
+			if len(address.LocalPart) > MaxLocalPartLength || len(address.String()) > MaxEmailAddressLength {
+				t.Fatalf("(email: %q) parsed into (address: %q) over length limits", email, address)
+			}
+
+			again, err := parse(address.String())
+			if err != nil {
+				t.Fatalf("(address: %q) parsed out of (email: %q) does not parse due to (err: %s)", address, email, err)
+			}
+
+			if again.String() != address.String() || len(again.Comments) != 0 {
+				t.Fatalf("(address: %q) parsed out of (email: %q) parsed again into (address: %q)", address, email, again)
+			}
+		}
+	})
+}
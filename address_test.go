@@ -0,0 +1,208 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// validAddresses - Addresses that parse along with their local part and
+// domain once comments are stripped
+var validAddresses = []struct {
+	email, local, domain string
+}{
+	{"john@example.com", "john", "example.com"},
+	{"John.Doe@Example.COM", "John.Doe", "Example.COM"},
+	{"j@x.io", "j", "x.io"},
+	{"john+tag@example.com", "john+tag", "example.com"},
+	{"first.middle.last@example.com", "first.middle.last", "example.com"},
+	{"!#$%&'*+-/=?^_`{|}~@example.com", "!#$%&'*+-/=?^_`{|}~", "example.com"},
+	{"user@mail-1.example.co.uk", "user", "mail-1.example.co.uk"},
+	{"user@123.example", "user", "123.example"},
+	{"user@xn--mller-kva.de", "user", "xn--mller-kva.de"},
+	{"user@müller.de", "user", "müller.de"},
+	{"user@Bücher.例え.テスト", "user", "Bücher.例え.テスト"},
+	{`"john"@example.com`, `"john"`, "example.com"},
+	{`"john doe"@example.com`, `"john doe"`, "example.com"},
+	{`"john..doe"@example.com`, `"john..doe"`, "example.com"},
+	{`".john"@example.com`, `".john"`, "example.com"},
+	{`"john@doe"@example.com`, `"john@doe"`, "example.com"},
+	{`"john\"doe"@example.com`, `"john\"doe"`, "example.com"},
+	{`"john\\doe"@example.com`, `"john\\doe"`, "example.com"},
+	{`"(not a comment)"@example.com`, `"(not a comment)"`, "example.com"},
+	{`""@example.com`, `""`, "example.com"},
+	{"user@[192.168.0.1]", "user", "[192.168.0.1]"},
+	{"user@[IPv6:2001:db8::1]", "user", "[IPv6:2001:db8::1]"},
+	{"user@[ipv6:::1]", "user", "[ipv6:::1]"},
+	{"(comment)john@example.com", "john", "example.com"},
+	{"john(comment)@example.com", "john", "example.com"},
+	{"john@(comment)example.com", "john", "example.com"},
+	{"john@example.com(comment)", "john", "example.com"},
+	{"john (comment) @ (comment) example.com (comment)", "john", "example.com"},
+	{"john(nested (comment))@example.com", "john", "example.com"},
+	{`john(escaped \) paren)@example.com`, "john", "example.com"},
+	{"john(has @ sign)@example.com", "john", "example.com"},
+	{strings.Repeat("a", 64) + "@example.com", strings.Repeat("a", 64), "example.com"},
+	{"john@" + strings.Repeat("a", 63) + ".com", "john", strings.Repeat("a", 63) + ".com"},
+	{strings.Repeat("a", 64) + "@" + strings.Repeat("b.", 93) + "com", strings.Repeat("a", 64), strings.Repeat("b.", 93) + "com"},
+}
+
+// invalidAddresses - Addresses that do not parse along with the rule they
+// break
+var invalidAddresses = []struct {
+	email, rule string
+}{
+	{"", RuleMissingAt},
+	{"john.example.com", RuleMissingAt},
+	{`"john@example.com"`, RuleMissingAt},
+	{"john(@example.com)", RuleMissingAt},
+	{"@example.com", RuleEmptyLocalPart},
+	{"(comment)@example.com", RuleEmptyLocalPart},
+	{strings.Repeat("a", 65) + "@example.com", RuleLocalPartTooLong},
+	{`"` + strings.Repeat("a", 63) + `"@example.com`, RuleLocalPartTooLong},
+	{"john@" + strings.Repeat("b.", 125) + "com", RuleAddressTooLong},
+	{"john@", RuleEmptyDomain},
+	{"john@(comment)", RuleEmptyDomain},
+	{"john@localhost", RuleSingleLabelDomain},
+	{"john@com", RuleSingleLabelDomain},
+	{"john@müller", RuleSingleLabelDomain},
+	{"john@example..com", RuleInvalidDomain},
+	{"john@.example.com", RuleInvalidDomain},
+	{"john@example.com.", RuleInvalidDomain},
+	{"john@-example.com", RuleInvalidDomain},
+	{"john@example-.com", RuleInvalidDomain},
+	{"john@exa_mple.com", RuleInvalidDomain},
+	{"john@exa mple.com", RuleInvalidDomain},
+	{"john@example(comment).com", RuleInvalidDomain},
+	{"john@ example.com", RuleInvalidDomain},
+	{"john@müller_.de", RuleInvalidIDN},
+	{"john@xn--a.de", RuleInvalidIDN},
+	{"john@ex\u200dample.com", RuleInvalidIDN},
+	{"john@" + strings.Repeat("a", 64) + ".com", RuleDomainLabelTooLong},
+	{"john@[192.168.0]", RuleInvalidIPLiteral},
+	{"john@[192.168.0.256]", RuleInvalidIPLiteral},
+	{"john@[2001:db8::1]", RuleInvalidIPLiteral},
+	{"john@[IPv6:192.168.0.1]", RuleInvalidIPLiteral},
+	{"john@[IPv6:zz::1]", RuleInvalidIPLiteral},
+	{"john@[tag:content]", RuleInvalidIPLiteral},
+	{"john@[192.168.0.1", RuleInvalidIPLiteral},
+	{".john@example.com", RuleLeadingDot},
+	{"john.@example.com", RuleTrailingDot},
+	{"john..doe@example.com", RuleConsecutiveDots},
+	{"jo hn@example.com", RuleInvalidLocalPart},
+	{" john@example.com", RuleInvalidLocalPart},
+	{"john @example.com", RuleInvalidLocalPart},
+	{"jo(comment)hn@example.com", RuleInvalidLocalPart},
+	{"john@doe@example.com", RuleInvalidLocalPart},
+	{"jo,hn@example.com", RuleInvalidLocalPart},
+	{"jo[hn]@example.com", RuleInvalidLocalPart},
+	{`jo\hn@example.com`, RuleInvalidLocalPart},
+	{`jo"hn"@example.com`, RuleInvalidLocalPart},
+	{"jöhn@example.com", RuleInvalidLocalPart},
+	{`"john"doe@example.com`, RuleInvalidQuotedString},
+	{`"jo"."hn"@example.com`, RuleInvalidQuotedString},
+	{"\"jo\thn\"@example.com", RuleInvalidQuotedString},
+	{"\"jöhn\"@example.com", RuleInvalidQuotedString},
+	{`"john@example.com`, RuleUnterminatedQuote},
+	{`john"@example.com`, RuleUnterminatedQuote},
+	{"john(comment@example.com", RuleUnterminatedComment},
+	{"john@example.com(comment", RuleUnterminatedComment},
+}
+
+func TestParseAddress(t *testing.T) {
+	Convey("Valid addresses are split into local part and domain", t, func() {
+		for _, tc := range validAddresses {
+			address, err := ParseAddress(tc.email)
+			So(err, ShouldBeNil)
+			So(address.LocalPart, ShouldEqual, tc.local)
+			So(address.Domain, ShouldEqual, tc.domain)
+			So(EmailSyntaxRule(tc.email), ShouldBeEmpty)
+		}
+	})
+
+	Convey("Invalid addresses report the rule they break", t, func() {
+		for _, tc := range invalidAddresses {
+			_, err := ParseAddress(tc.email)
+			So(err, ShouldNotBeNil)
+			So(err.(*AddressError).Rule, ShouldEqual, tc.rule)
+			So(EmailSyntaxRule(tc.email), ShouldEqual, tc.rule)
+		}
+	})
+
+	Convey("Comments are collected and stripped", t, func() {
+		address, err := ParseAddress("(one)john(two)@example.com (three)")
+		So(err, ShouldBeNil)
+		So(address.Comments, ShouldResemble, []string{"one", "two", "three"})
+		So(address.String(), ShouldEqual, "john@example.com")
+		So(EmailDomain("john@example.com (three)"), ShouldEqual, "example.com")
+	})
+
+	Convey("Quoted local parts and IP literals are told apart", t, func() {
+		address, err := ParseAddress(`"john doe"@[IPv6:::1]`)
+		So(err, ShouldBeNil)
+		So(address.Quoted(), ShouldBeTrue)
+		So(address.IPLiteral(), ShouldBeTrue)
+
+		address, err = ParseAddress("john@example.com")
+		So(err, ShouldBeNil)
+		So(address.Quoted(), ShouldBeFalse)
+		So(address.IPLiteral(), ShouldBeFalse)
+	})
+
+	Convey("UTF-8 local parts are accepted only when asked for", t, func() {
+		for _, email := range []string{"jöhn@example.com", "用户@例子.广告", `"jöhn doe"@müller.de`, "δοκιμή@παράδειγμα.δοκιμή"} {
+			_, err := ParseAddress(email)
+			So(err, ShouldNotBeNil)
+
+			address, err := ParseInternationalAddress(email)
+			So(err, ShouldBeNil)
+			So(address.String(), ShouldEqual, email)
+		}
+
+		for email, rule := range map[string]string{
+			"jö..hn@example.com":   RuleConsecutiveDots,
+			"jö hn@example.com":    RuleInvalidLocalPart,
+			"j\xffhn@example.com":  RuleInvalidLocalPart,
+			"\"jö\"hn@example.com": RuleInvalidQuotedString,
+		} {
+			_, err := ParseInternationalAddress(email)
+			So(err, ShouldNotBeNil)
+			So(err.(*AddressError).Rule, ShouldEqual, rule)
+		}
+	})
+
+	Convey("Internationalized domains are normalized to A-labels", t, func() {
+		So(NormalizeDomain("Müllmail.DE."), ShouldEqual, "xn--mllmail-n2a.de")
+		So(NormalizeDomain("xn--mllmail-n2a.de"), ShouldEqual, "xn--mllmail-n2a.de")
+		So(NormalizeDomain("müllmail。de"), ShouldEqual, "xn--mllmail-n2a.de")
+		So(NormalizeDomain("mailinator.com"), ShouldEqual, "mailinator.com")
+		So(EmailDomain("jöhn@müllmail.de"), ShouldEqual, "müllmail.de")
+	})
+
+	Convey("Failed validation carries the rule in error info", t, func() {
+		address, resp := ValidateEmail("john..doe@example.com", AddressOptions{})
+		So(address, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(resp.Error.Info["rule"], ShouldEqual, RuleConsecutiveDots)
+	})
+
+	Convey("Single label domains are accepted only if asked to", t, func() {
+		_, resp := ValidateEmail("john@localhost", AddressOptions{})
+		So(resp.Error.Info["rule"], ShouldEqual, RuleSingleLabelDomain)
+
+		address, resp := ValidateEmail("john@localhost", AddressOptions{SingleLabelDomains: true})
+		So(resp, ShouldBeNil)
+		So(address.Domain, ShouldEqual, "localhost")
+
+		_, resp = ValidateEmail("john@-localhost", AddressOptions{SingleLabelDomains: true})
+		So(resp.Error.Info["rule"], ShouldEqual, RuleInvalidDomain)
+
+		So(DomainSyntaxRule("localhost"), ShouldEqual, RuleSingleLabelDomain)
+		So(ValidateDomain("localhost"), ShouldNotBeNil)
+	})
+}
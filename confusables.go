@@ -0,0 +1,301 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultProtectedDomains - Provider domains lookalikes of which are rejected
+// unless configured otherwise
+const DefaultProtectedDomains = "gmail.com,googlemail.com,yahoo.com,outlook.com,hotmail.com,live.com,icloud.com,aol.com,protonmail.com,gmx.com,yandex.com,mail.ru"
+
+const (
+	ConfusableSkeleton    = "skeleton"
+	ConfusableMixedScript = "mixed_script"
+)
+
+// embeddedConfusables - Subset of Unicode confusables table compiled into the
+// binary. Served when the full table can not be loaded.
+//
+//go:embed assets/confusables.txt
+var embeddedConfusables []byte
+
+// allowedScriptSets - Scripts that may be mixed within a single label (UTS #39
+// highly restrictive profile). Common and inherited characters such as digits
+// and hyphens belong to no script at all.
+var allowedScriptSets = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// Confusables - Unicode confusables table (UTS #39) along with protected
+// domains lookalikes of which are reported
+type Confusables struct {
+	// table maps characters to their prototypes
+	table map[rune]string
+
+	// protected maps skeletons to protected domains they belong to
+	protected map[string]string
+}
+
+// ConfusableMatch - Reason domain is reported as confusable along with the
+// protected domain it looks like, if any, and scripts its labels mix.
+type ConfusableMatch struct {
+	Reason    string
+	Protected string
+	Scripts   []string
+}
+
+// ParseConfusables - Will parse table in the format of Unicode confusables.txt
+// ("source ; target ; type # comment"). Source is a single code point and
+// target a sequence of them, both in hex.
+func ParseConfusables(r io.Reader) (*Confusables, error) {
+	c := &Confusables{table: map[rune]string{}, protected: map[string]string{}}
+	scanner := bufio.NewScanner(r)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		if hash := strings.IndexByte(text, '#'); hash >= 0 {
+			text = text[:hash]
+		}
+
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		fields := strings.Split(text, ";")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("malformed confusable on (line: %d)", line)
+		}
+
+		source, err := parseCodePoints(fields[0])
+		if err != nil || len(source) != 1 {
+			return nil, fmt.Errorf("malformed confusable source on (line: %d)", line)
+		}
+
+		target, err := parseCodePoints(fields[1])
+		if err != nil || len(target) == 0 {
+			return nil, fmt.Errorf("malformed confusable target on (line: %d)", line)
+		}
+
+		c.table[source[0]] = string(target)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// parseCodePoints - Will parse whitespace delimited hex code points
+func parseCodePoints(s string) ([]rune, error) {
+	runes := []rune{}
+
+	for _, field := range strings.Fields(s) {
+		r, err := strconv.ParseUint(field, 16, 32)
+		if err != nil {
+			return nil, err
+		}
+		runes = append(runes, rune(r))
+	}
+
+	return runes, nil
+}
+
+// LoadConfusables - Will load and parse confusables table from file
+func LoadConfusables(source string) (*Confusables, error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseConfusables(f)
+}
+
+// NewEmbeddedConfusables - Will parse confusables table subset embedded into
+// the binary
+func NewEmbeddedConfusables() *Confusables {
+	c, err := ParseConfusables(bytes.NewReader(embeddedConfusables))
+	if err != nil {
+		log.Errorf("[confusables] Could not load embedded confusables due to (err: %s)", err)
+		return &Confusables{table: map[rune]string{}, protected: map[string]string{}}
+	}
+
+	return c
+}
+
+// NewConfusables - Will load confusables table and protected domains the way
+// environment configures them. Embedded subset of the table is served when
+// the table can not be loaded.
+func NewConfusables() *Confusables {
+	source := OptionString("CONFUSABLES_SOURCE", "services/unicode/confusables.txt")
+
+	c, err := LoadConfusables(source)
+	if err != nil {
+		log.Warnf("[confusables] Failed to load confusables (source: %s) due to (err: %s). Falling back to embedded subset...", source, err)
+		c = NewEmbeddedConfusables()
+	}
+
+	c.Protect(strings.Split(OptionString("PROTECTED_DOMAINS", DefaultProtectedDomains), ","))
+
+	log.Infof("[confusables] Loaded (confusables: %d) protecting (domains: %d)", c.Len(), len(c.protected))
+	return c
+}
+
+// Protect - Will report lookalikes of provided domains from now on
+func (c *Confusables) Protect(domains []string) {
+	for _, domain := range domains {
+		if domain = NormalizeDomain(domain); domain != "" {
+			c.protected[c.Skeleton(domain)] = domain
+		}
+	}
+}
+
+// Len - Will return number of characters in the table
+func (c *Confusables) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	return len(c.table)
+}
+
+// Skeleton - Will return UTS #39 skeleton of provided domain, that is its
+// Unicode form with every character replaced by its prototype. Lookalike
+// domains share the same skeleton.
+func (c *Confusables) Skeleton(domain string) string {
+	if unicodeDomain, err := idna.Lookup.ToUnicode(domain); err == nil {
+		domain = unicodeDomain
+	}
+
+	var skeleton strings.Builder
+	for _, r := range norm.NFD.String(domain) {
+		if prototype, ok := c.table[r]; ok {
+			skeleton.WriteString(prototype)
+		} else {
+			skeleton.WriteRune(r)
+		}
+	}
+
+	return strings.ToLower(norm.NFD.String(skeleton.String()))
+}
+
+// Check - Will check if provided domain mixes scripts within a label or looks
+// like one of protected domains without being it. Nil table checks nothing.
+func (c *Confusables) Check(domain string) (*ConfusableMatch, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	domain = NormalizeDomain(domain)
+
+	if protected, ok := c.protected[c.Skeleton(domain)]; ok && protected != domain {
+		return &ConfusableMatch{Reason: ConfusableSkeleton, Protected: protected}, true
+	}
+
+	unicodeDomain, err := idna.Lookup.ToUnicode(domain)
+	if err != nil {
+		return nil, false
+	}
+
+	for _, label := range strings.Split(unicodeDomain, ".") {
+		if scripts := labelScripts(label); !allowedScripts(scripts) {
+			return &ConfusableMatch{Reason: ConfusableMixedScript, Scripts: scripts}, true
+		}
+	}
+
+	return nil, false
+}
+
+// labelScripts - Will return sorted names of scripts characters of provided
+// label belong to. ASCII letters are Latin and the rest of ASCII is common,
+// script tables are only looked through for other characters.
+func labelScripts(label string) []string {
+	seen := map[string]bool{}
+
+	for _, r := range label {
+		if r < utf8.RuneSelf {
+			if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') {
+				seen["Latin"] = true
+			}
+
+			continue
+		}
+
+		if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+
+		for name, table := range unicode.Scripts {
+			if unicode.Is(table, r) {
+				seen[name] = true
+				break
+			}
+		}
+	}
+
+	scripts := []string{}
+	for name := range seen {
+		scripts = append(scripts, name)
+	}
+
+	sort.Strings(scripts)
+	return scripts
+}
+
+// allowedScripts - Will check if provided scripts may be mixed
+func allowedScripts(scripts []string) bool {
+	if len(scripts) < 2 {
+		return true
+	}
+
+	for _, set := range allowedScriptSets {
+		if containsAll(set, scripts) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsAll - Will check if every one of values is in the set
+func containsAll(set, values []string) bool {
+	for _, value := range values {
+		found := false
+		for _, s := range set {
+			if s == value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConfusables(t *testing.T) {
+	confusables := NewEmbeddedConfusables()
+	confusables.Protect([]string{"gmail.com", "Mail.RU", "paypal.com", ""})
+
+	Convey("Table is parsed out of Unicode confusables format", t, func() {
+		So(confusables.Len(), ShouldBeGreaterThan, 30)
+
+		c, err := ParseConfusables(strings.NewReader("\ufeff# comment\n\n0430 ;\t0061 ;\tMA\t# ( а → a )\n006D ; 0072 006E ; MA\n"))
+		So(err, ShouldBeNil)
+		So(c.Len(), ShouldEqual, 2)
+		So(c.Skeleton("mаil"), ShouldEqual, "rnail")
+
+		for _, table := range []string{"0430", "0430 ; zz ; MA", "0430 0431 ; 0061 ; MA", "0430 ; ; MA"} {
+			_, err := ParseConfusables(strings.NewReader(table))
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Lookalikes share skeleton with domain they look like", t, func() {
+		So(confusables.Skeleton("gmaіl.com"), ShouldEqual, confusables.Skeleton("gmail.com"))
+		So(confusables.Skeleton("xn--gmal-n9d.com"), ShouldEqual, confusables.Skeleton("gmail.com"))
+		So(confusables.Skeleton("rnail.ru"), ShouldEqual, confusables.Skeleton("mail.ru"))
+		So(confusables.Skeleton("gmail.com"), ShouldNotEqual, confusables.Skeleton("gmall.com"))
+	})
+
+	Convey("Lookalikes of protected domains are reported", t, func() {
+		for domain, protected := range map[string]string{
+			"gmaіl.com":        "gmail.com",
+			"xn--gmal-n9d.com": "gmail.com",
+			"gmai1.com":        "gmail.com",
+			"GMAIL.com":        "",
+			"rnail.ru":         "mail.ru",
+			"раураӏ.com":       "paypal.com",
+		} {
+			match, ok := confusables.Check(domain)
+			if protected == "" {
+				So(ok, ShouldBeFalse)
+				continue
+			}
+
+			So(ok, ShouldBeTrue)
+			So(match.Reason, ShouldEqual, ConfusableSkeleton)
+			So(match.Protected, ShouldEqual, protected)
+		}
+	})
+
+	Convey("Labels mixing scripts are reported", t, func() {
+		match, ok := confusables.Check("exаmple.com")
+		So(ok, ShouldBeTrue)
+		So(match.Reason, ShouldEqual, ConfusableMixedScript)
+		So(match.Scripts, ShouldResemble, []string{"Cyrillic", "Latin"})
+
+		for _, domain := range []string{"example.com", "пример.рф", "ドメイン名例.jp", "日本abc.jp", "müller-1.de", "example.рф"} {
+			_, ok := confusables.Check(domain)
+			So(ok, ShouldBeFalse)
+		}
+
+		So(labelScripts("mail-123"), ShouldResemble, []string{"Latin"})
+		So(labelScripts("123-456"), ShouldResemble, []string{})
+		So(labelScripts("müller"), ShouldResemble, []string{"Latin"})
+		So(labelScripts("exаmple"), ShouldResemble, []string{"Cyrillic", "Latin"})
+	})
+
+	Convey("Nil table checks nothing", t, func() {
+		var c *Confusables
+		_, ok := c.Check("gmaіl.com")
+		So(ok, ShouldBeFalse)
+		So(c.Len(), ShouldEqual, 0)
+	})
+}
@@ -5,55 +5,414 @@
 package main
 
 import (
+	"fmt"
 	"io/ioutil"
-	"strings"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
+	"golang.org/x/crypto/ed25519"
 )
 
+// emptyIndex - Served until first successful load
+var emptyIndex = NewDomainIndex(nil, nil)
+
 // DisposableEmails -
 type DisposableEmails struct {
-	Emails []string
-	Source string
+	// Sources - Named sources loaded in order and merged into single list
+	Sources []ListSource
+
+	// AllowSources - Named sources of domains that are never reported as
+	// disposable even if listed by any of Sources
+	AllowSources []ListSource
+
+	SuffixesSource string
+
+	// MaxAge - List any source of which was not successfully read (or
+	// fetched) for longer than this is reported as stale. Zero disables
+	// staleness checks.
+	MaxAge time.Duration
+
+	// index holds current *DomainIndex. Loads build new index off to the side
+	// and swap it in so lookups never see partially loaded list.
+	index atomic.Value
+	load  sync.Mutex
+
+	// serving serializes swaps, rollbacks and releases so that whether a
+	// snapshot is pinned is checked and acted on at once
+	serving sync.Mutex
+
+	// HTTPClient - Client remote sources are fetched with
+	HTTPClient *http.Client
+
+	// MaxFetchSize - Remote list and signature bodies larger than this many
+	// bytes fail the fetch, so do compressed lists decompressing to more.
+	// Zero means DefaultMaxFetchSize.
+	MaxFetchSize int64
+
+	// remotes holds fetch state of remote sources keyed by their url
+	remotes   map[string]*remoteList
+	remotesMu sync.Mutex
+
+	// PublicKeys - When set every list file has to carry detached ed25519
+	// signature made by one of these keys
+	PublicKeys []ed25519.PublicKey
+
+	// EmbeddedFallback - Serve list embedded into the binary when none of
+	// the sources can be loaded and there is no previously loaded list.
+	// Enabled by default, health report tells while it is serving.
+	EmbeddedFallback bool
+
+	// loadErr holds loadFailure of the latest load
+	loadErr atomic.Value
+
+	// fallback is 1 while embedded list is serving
+	fallback int32
+
+	// HistorySize - Number of snapshots of loaded lists kept around to
+	// diff and roll back to
+	HistorySize int
+
+	// history holds loaded lists, oldest first. pinned is the one rolled
+	// back to, if any. activations tell which list was serving when.
+	history     []*DomainIndex
+	pinned      *DomainIndex
+	activations []activation
+	historyMu   sync.Mutex
+}
+
+// loadFailure - Error of a failed load and time it happened
+type loadFailure struct {
+	err string
+	at  time.Time
 }
 
-// Load -
+// Load - Will (re)load list out of all sources and public suffix list. Every
+// source has to load, on failure of any of them previously loaded list keeps
+// serving. If there is no previously loaded list embedded one is served
+// instead when EmbeddedFallback is enabled.
 func (de *DisposableEmails) Load() error {
-	log.Infof("[load] About to start loading disposable emails from (source: %s)", de.Source)
+	de.load.Lock()
+	defer de.load.Unlock()
+
+	suffixes, err := LoadPublicSuffixList(de.SuffixesSource)
+	if err != nil {
+		log.Warnf("[load] Failed to load public suffix list (source: %s) due to (err: %s). Treating last label as public suffix...", de.SuffixesSource, err)
+	} else {
+		log.Infof("[load] Loaded (rules: %d) from public suffix list (source: %s)", suffixes.Len(), de.SuffixesSource)
+	}
+
+	index, err := de.loadList(suffixes)
+	if err != nil {
+		de.failLoad(err)
+
+		if de.Loaded() || !de.EmbeddedFallback {
+			return err
+		}
+
+		log.Errorf("[load] Could not load any disposable emails yet. Falling back to embedded list (date: %s)...", EmbeddedListDate())
+
+		if index = NewEmbeddedIndex(suffixes); len(de.AllowSources) > 0 {
+			if allow, err := de.loadAllowlist(suffixes); err == nil {
+				index.SetAllowlist(allow)
+			}
+		}
+
+		atomic.StoreInt32(&de.fallback, 1)
+	} else {
+		de.loadErr.Store(loadFailure{})
+		atomic.StoreInt32(&de.fallback, 0)
+	}
+
+	old := de.Swap(index)
+	metricListLoads.Add(1)
+
+	log.Infof("[load] Swapped (old_domains: %d) for (new_domains: %d) - (version: %s) loaded from (sources: %d)", old.Len(), de.Len(), de.Index().Version(), len(de.Sources))
+	return nil
+}
+
+// loadList - Will build new index out of all sources and allowlist sources
+func (de *DisposableEmails) loadList(suffixes *PublicSuffixList) (*DomainIndex, error) {
+	index := NewDomainIndex(nil, suffixes)
+
+	for _, source := range de.Sources {
+		log.Infof("[load] About to start loading disposable emails from (source: %s) - (path: %s)", source.Name, source.Path)
+
+		if err := de.loadSource(index, source); err != nil {
+			log.Errorf("[load] Failed to load (source: %s) - (path: %s) due to (err: %s)", source.Name, source.Path, err)
+			return nil, err
+		}
+
+		if source.IsRemote() {
+			index.markFetched(de.remote(source).succeededAt())
+		}
+	}
+
+	if len(de.AllowSources) > 0 {
+		allow, err := de.loadAllowlist(suffixes)
+		if err != nil {
+			return nil, err
+		}
+
+		index.SetAllowlist(allow)
+	}
+
+	return index, nil
+}
+
+// loadAllowlist - Will build new allowlist index out of allowlist sources
+// against provided public suffix list
+func (de *DisposableEmails) loadAllowlist(suffixes *PublicSuffixList) (*DomainIndex, error) {
+	allow := NewDomainIndex(nil, suffixes)
+
+	for _, source := range de.AllowSources {
+		log.Infof("[load] About to start loading allowlist from (source: %s) - (path: %s)", source.Name, source.Path)
+
+		if err := de.loadSource(allow, source); err != nil {
+			log.Errorf("[load] Failed to load allowlist (source: %s) - (path: %s) due to (err: %s)", source.Name, source.Path, err)
+			return nil, err
+		}
+	}
+
+	return allow, nil
+}
+
+// ServingFallback - Will check if embedded list is serving because none of
+// the configured sources could be loaded yet.
+func (de *DisposableEmails) ServingFallback() bool {
+	return atomic.LoadInt32(&de.fallback) == 1
+}
+
+// failLoad - Will record load failure so it can be reported by health
+func (de *DisposableEmails) failLoad(err error) error {
+	metricListLoadErrors.Add(1)
+	de.loadErr.Store(loadFailure{err: err.Error(), at: time.Now()})
+	return err
+}
+
+// LoadError - Will return error of the latest load and when it happened. Empty
+// error is returned when the latest load succeeded.
+func (de *DisposableEmails) LoadError() (string, time.Time) {
+	failure, _ := de.loadErr.Load().(loadFailure)
+	return failure.err, failure.at
+}
+
+// loadSource - Will index every file of provided source or fetched remote
+// source.
+func (de *DisposableEmails) loadSource(index *DomainIndex, source ListSource) error {
+	return de.readSource(source, func(file string, data []byte) error {
+		return addListData(index, ListFile{Source: source.Name, Path: file}, data, de.maxFetchSize())
+	})
+}
+
+// readSource - Will read (or fetch) every file of provided source, verify its
+// signature and hand its contents over to fn. Remote list becomes the last
+// good copy only once it is verified and fn accepts it.
+func (de *DisposableEmails) readSource(source ListSource, fn func(file string, data []byte) error) error {
+	if source.IsRemote() {
+		fetched, err := de.fetchRemote(source)
+		if err != nil {
+			return err
+		}
+
+		if err := de.verifyListData(source, source.Path, fetched.data); err != nil {
+			return de.remote(source).reject(err)
+		}
+
+		if err := fn(source.Path, fetched.data); err != nil {
+			return de.remote(source).reject(err)
+		}
+
+		de.remote(source).commit(fetched)
+		return nil
+	}
 
-	data, err := ioutil.ReadFile(de.Source)
+	files, err := source.Files()
 	if err != nil {
-		log.Errorf("[load] Failed to load (source: %s) due to (err: %s)", de.Source, err)
 		return err
 	}
-	emails := string(data)
-	de.Emails = strings.Split(emails, "\n")
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := de.verifyListData(source, file, data); err != nil {
+			return err
+		}
+
+		if err := fn(file, data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addListData - Will parse provided list file contents in whatever format
+// they are in and index them. Compressed contents may decompress to no more
+// than maxSize bytes.
+func addListData(index *DomainIndex, file ListFile, data []byte, maxSize int64) error {
+	entries, format, err := ParseListLimited(file.Path, data, maxSize)
+	if err != nil {
+		return fmt.Errorf("could not parse (file: %s) due to (err: %s)", file.Path, err)
+	}
+
+	file.Format = format
+	added := index.AddEntries(entries, index.AddFile(file))
+
+	log.Infof("[load] Loaded (new_domains: %d) out of (entries: %d) from (source: %s) - (file: %s) - (format: %s)", added, len(entries), file.Source, file.Path, format)
 	return nil
 }
 
+// Build - Will index provided domains replacing whatever was loaded before.
+// Blank and duplicate domains are dropped. Allowlist is kept, it was built
+// against the same public suffix list and is shared as it is never modified.
+func (de *DisposableEmails) Build(domains []string) {
+	index := NewDomainIndex(domains, de.Index().Suffixes())
+	index.SetAllowlist(de.Index().Allowlist())
+	de.Swap(index)
+}
+
+// Swap - Will atomically replace current index with provided one and return
+// the one that was serving until now. Index is recorded in history as well.
+// While rolled back to a snapshot index is only recorded and the snapshot
+// keeps serving.
+func (de *DisposableEmails) Swap(index *DomainIndex) *DomainIndex {
+	de.serving.Lock()
+	defer de.serving.Unlock()
+
+	index.loadedAt = time.Now()
+	de.record(index)
+
+	if pinned := de.Pinned(); pinned != "" {
+		log.Warnf("[swap] Rolled back (version: %s) keeps serving instead of loaded (version: %s)", pinned, index.Version())
+		return de.Index()
+	}
+
+	return de.serve(index)
+}
+
+// serve - Will atomically replace current index with provided one and return
+// the one that was serving until now.
+func (de *DisposableEmails) serve(index *DomainIndex) *DomainIndex {
+	old := de.Index()
+	de.index.Store(index)
+	de.activate(index)
+
+	metricListDomains.Set(int64(index.Len()))
+	metricListAllowedDomains.Set(int64(index.Allowlist().Len()))
+	metricListPreviousDomains.Set(int64(old.Len()))
+	return old
+}
+
+// Index - Will return currently serving index. Empty index is returned before
+// first load.
+func (de *DisposableEmails) Index() *DomainIndex {
+	if index, ok := de.index.Load().(*DomainIndex); ok {
+		return index
+	}
+
+	return emptyIndex
+}
+
+// Loaded - Will check if list was successfully loaded at least once
+func (de *DisposableEmails) Loaded() bool {
+	_, ok := de.index.Load().(*DomainIndex)
+	return ok
+}
+
+// LoadedAt - Will return time of last successful load. Loads keep happening
+// while rolled back so that is the time of the latest snapshot rather than
+// the one serving.
+func (de *DisposableEmails) LoadedAt() time.Time {
+	if index := de.latest(); index != nil {
+		return index.LoadedAt()
+	}
+
+	return de.Index().LoadedAt()
+}
+
+// FetchedAt - Will return time the oldest source of the latest loaded list
+// was successfully read. Remote sources failing to fetch keep it from moving
+// forward even though their last good copy keeps loading.
+func (de *DisposableEmails) FetchedAt() time.Time {
+	if index := de.latest(); index != nil {
+		return index.FetchedAt()
+	}
+
+	return de.Index().FetchedAt()
+}
+
+// Stale - Will check if any source of the list was not successfully read for
+// longer than allowed by MaxAge. List that was never loaded is not stale,
+// just not loaded.
+func (de *DisposableEmails) Stale() bool {
+	if de.MaxAge <= 0 || !de.Loaded() {
+		return false
+	}
+
+	return time.Since(de.FetchedAt()) > de.MaxAge
+}
+
 // GetAll -
 func (de *DisposableEmails) GetAll() []string {
-	return de.Emails
+	return de.Index().All()
 }
 
 // DomainExists -
 func (de *DisposableEmails) DomainExists(domain string) bool {
-	return StringInSlice(domain, de.Emails)
+	return de.Index().Contains(domain)
+}
+
+// Match - Will return list entry matching domain of provided email address.
+// Subdomains of listed domains match as well unless entry is exact-only.
+func (de *DisposableEmails) Match(email string) (string, bool) {
+	domain := EmailDomain(email)
+	if domain == "" {
+		return "", false
+	}
+
+	return de.Index().Match(domain)
 }
 
-// IsOK -
+// Provenance - Will return names of sources provided list entry was loaded
+// from. Entries that are not listed have no sources.
+func (de *DisposableEmails) Provenance(entry string) []string {
+	return de.Index().Provenance(entry)
+}
+
+// RegistrableDomain - Will return registrable domain (eTLD+1) of provided
+// domain according to loaded public suffix list.
+func (de *DisposableEmails) RegistrableDomain(domain string) string {
+	return de.Index().Suffixes().RegistrableDomain(domain)
+}
+
+// Allowed - Will return allowlist entry matching domain of provided email
+// address.
+func (de *DisposableEmails) Allowed(email string) (string, bool) {
+	domain := EmailDomain(email)
+	if domain == "" {
+		return "", false
+	}
+
+	return de.Index().Allow(domain)
+}
+
+// IsOK - Will check if provided email address is not disposable. Allowlist is
+// consulted first and wins over the list.
 func (de *DisposableEmails) IsOK(email string) bool {
-	for _, domain := range de.GetAll() {
-		if domain != "" {
-			if strings.Contains(email, "@") {
-				splitted := strings.Split(email, "@")
-
-				if splitted[len(splitted)-1] == domain {
-					log.Infof("[is_ok] Caught illegal (domain: %s) for (email: %+v)", domain, splitted)
-					return false
-				}
-			}
-		}
+	if entry, ok := de.Allowed(email); ok {
+		log.Infof("[is_ok] Allowing (domain: %s) matching allowlist (entry: %s) for (email: %s)", EmailDomain(email), entry, email)
+		return true
+	}
+
+	if entry, ok := de.Match(email); ok {
+		log.Infof("[is_ok] Caught illegal (domain: %s) matching (entry: %s) for (email: %s)", EmailDomain(email), entry, email)
+		return false
 	}
 
 	return true
@@ -61,12 +420,26 @@ func (de *DisposableEmails) IsOK(email string) bool {
 
 // Len -
 func (de *DisposableEmails) Len() int {
-	return len(de.Emails)
+	return de.Index().Len()
 }
 
 // DisposableEmails -
 func NewDisposableEmails() (*DisposableEmails, error) {
+	keys, err := ParsePublicKeys(OptionString("DISPOSABLE_EMAILS_PUBLIC_KEYS", ""))
+	if err != nil {
+		return nil, err
+	}
+
 	return &DisposableEmails{
-		Source: OptionString("DISPOSABLE_EMAILS_SOURCE", "services/burner/emails.txt"),
+		Sources:        ParseListSources(OptionString("DISPOSABLE_EMAILS_SOURCES", "burner="+OptionString("DISPOSABLE_EMAILS_SOURCE", "services/burner/emails.txt"))),
+		SuffixesSource: OptionString("PUBLIC_SUFFIX_LIST_SOURCE", "services/publicsuffix/public_suffix_list.dat"),
+		AllowSources:   ParseListSources(OptionString("DISPOSABLE_EMAILS_ALLOWLIST", "")),
+		HTTPClient:     &http.Client{Timeout: OptionDuration("DISPOSABLE_EMAILS_FETCH_TIMEOUT", RemoteFetchTimeout)},
+		MaxFetchSize:   int64(OptionInt("DISPOSABLE_EMAILS_MAX_FETCH_SIZE", DefaultMaxFetchSize)),
+		MaxAge:         OptionDuration("DISPOSABLE_EMAILS_MAX_AGE", 0),
+		HistorySize:    OptionInt("DISPOSABLE_EMAILS_HISTORY", DefaultHistorySize),
+		PublicKeys:     keys,
+
+		EmbeddedFallback: OptionBool("DISPOSABLE_EMAILS_FALLBACK", true),
 	}, nil
 }
@@ -5,6 +5,12 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -12,18 +18,375 @@ import (
 
 func TestDisposableEmails(t *testing.T) {
 	var emails *DisposableEmails
-	var err error
 
 	Convey("Disposable emails loaded successfully", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		source := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(source, []byte("wiki.8191.at\nmailinator.com\n"), 0644), ShouldBeNil)
+
+		defer os.Setenv("DISPOSABLE_EMAILS_SOURCE", os.Getenv("DISPOSABLE_EMAILS_SOURCE"))
+		os.Setenv("DISPOSABLE_EMAILS_SOURCE", source)
+
 		emails, err = NewDisposableEmails()
 		So(emails, ShouldNotBeNil)
 		So(err, ShouldBeNil)
 
-		err := emails.Load()
+		err = emails.Load()
 		So(err, ShouldBeNil)
 
-		So(emails.Len(), ShouldBeGreaterThan, 0)
+		So(emails.ServingFallback(), ShouldBeFalse)
+		So(emails.Len(), ShouldEqual, 2)
 		So(emails.DomainExists("wiki.8191.at"), ShouldBeTrue)
 		So(emails.DomainExists("zang.io"), ShouldBeFalse)
 	})
 }
+
+func TestDisposableEmailsIndex(t *testing.T) {
+	Convey("Domains are normalized and deduplicated when indexed", t, func() {
+		emails := &DisposableEmails{}
+		emails.Build([]string{"Mailinator.COM", " mailinator.com\r", "", "guerrillamail.com.", "mailinator.com"})
+
+		So(emails.Len(), ShouldEqual, 2)
+		So(emails.GetAll(), ShouldResemble, []string{"mailinator.com", "guerrillamail.com"})
+		So(emails.DomainExists("MAILINATOR.com"), ShouldBeTrue)
+		So(emails.DomainExists("guerrillamail.com"), ShouldBeTrue)
+		So(emails.DomainExists("gmail.com"), ShouldBeFalse)
+	})
+
+	Convey("Only the domain after the last @ is matched", t, func() {
+		emails := &DisposableEmails{}
+		emails.Build([]string{"mailinator.com"})
+
+		So(emails.IsOK("john@Mailinator.com"), ShouldBeFalse)
+		So(emails.IsOK(`"john@mailinator.com"@gmail.com`), ShouldBeTrue)
+		So(emails.IsOK("john@gmail.com"), ShouldBeTrue)
+		So(emails.IsOK("mailinator.com"), ShouldBeTrue)
+	})
+
+	Convey("Internationalized domains are matched whichever form is listed", t, func() {
+		emails := &DisposableEmails{}
+		emails.Build([]string{"müllmail.de", "xn--wegwerfmil-x5a.de"})
+
+		So(emails.GetAll(), ShouldResemble, []string{"xn--mllmail-n2a.de", "xn--wegwerfmil-x5a.de"})
+		So(emails.IsOK("john@müllmail.de"), ShouldBeFalse)
+		So(emails.IsOK("john@MÜLLMAIL.de"), ShouldBeFalse)
+		So(emails.IsOK("john@mx.xn--mllmail-n2a.de"), ShouldBeFalse)
+		So(emails.IsOK("jöhn@wegwerfmäil.de"), ShouldBeFalse)
+		So(emails.IsOK("jöhn@müller.de"), ShouldBeTrue)
+		So(emails.DomainExists("müllmail.de"), ShouldBeTrue)
+	})
+
+	Convey("Subdomains of listed domains are matched", t, func() {
+		emails := &DisposableEmails{}
+		emails.Build([]string{"mailinator.com", "=guerrillamail.com", "wiki.8191.at"})
+
+		entry, ok := emails.Match("john@mx1.Mailinator.com")
+		So(ok, ShouldBeTrue)
+		So(entry, ShouldEqual, "mailinator.com")
+
+		entry, ok = emails.Match("john@a.b.wiki.8191.at")
+		So(ok, ShouldBeTrue)
+		So(entry, ShouldEqual, "wiki.8191.at")
+
+		_, ok = emails.Match("john@8191.at")
+		So(ok, ShouldBeFalse)
+
+		_, ok = emails.Match("john@notmailinator.com")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Exact-only entries do not match subdomains", t, func() {
+		emails := &DisposableEmails{}
+		emails.Build([]string{"=guerrillamail.com"})
+
+		So(emails.DomainExists("guerrillamail.com"), ShouldBeTrue)
+
+		entry, ok := emails.Match("john@guerrillamail.com")
+		So(ok, ShouldBeTrue)
+		So(entry, ShouldEqual, "guerrillamail.com")
+
+		_, ok = emails.Match("john@anything.guerrillamail.com")
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Public suffixes never block their subdomains", t, func() {
+		emails := &DisposableEmails{}
+		suffixes, _ := ParsePublicSuffixList(strings.NewReader(testPublicSuffixList))
+		emails.Swap(NewDomainIndex([]string{"co.uk", "github.io", "burner.github.io"}, suffixes))
+
+		_, ok := emails.Match("john@example.co.uk")
+		So(ok, ShouldBeFalse)
+
+		_, ok = emails.Match("john@someone.github.io")
+		So(ok, ShouldBeFalse)
+
+		entry, ok := emails.Match("john@mx.burner.github.io")
+		So(ok, ShouldBeTrue)
+		So(entry, ShouldEqual, "burner.github.io")
+
+		So(emails.RegistrableDomain("mx.burner.github.io"), ShouldEqual, "burner.github.io")
+	})
+
+	Convey("Entries remember their line and lists are versioned by content", t, func() {
+		index := NewDomainIndex([]string{"", "mailinator.com", "", "=guerrillamail.com"}, nil)
+
+		So(index.Line("mailinator.com"), ShouldEqual, 2)
+		So(index.Line("guerrillamail.com"), ShouldEqual, 4)
+		So(index.Line("gmail.com"), ShouldEqual, 0)
+
+		So(index.Version(), ShouldHaveLength, 16)
+		So(index.Version(), ShouldEqual, NewDomainIndex([]string{"mailinator.com", "=guerrillamail.com"}, nil).Version())
+		So(index.Version(), ShouldNotEqual, NewDomainIndex([]string{"mailinator.com", "guerrillamail.com"}, nil).Version())
+	})
+
+	Convey("Nothing is blocked before first load", t, func() {
+		emails := &DisposableEmails{}
+		So(emails.DomainExists("mailinator.com"), ShouldBeFalse)
+		So(emails.IsOK("john@mailinator.com"), ShouldBeTrue)
+	})
+}
+
+func TestDisposableEmailsSources(t *testing.T) {
+	Convey("Sources are merged keeping provenance of every domain", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(os.Mkdir(filepath.Join(dir, "internal"), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "burner.txt"), []byte("mailinator.com\nguerrillamail.com\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "internal", "a.txt"), []byte("example.org\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "internal", "b.txt"), []byte("\nmailinator.com\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "extra-1.lst"), []byte("wiki.8191.at\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{Sources: []ListSource{
+			{Name: "burner", Path: filepath.Join(dir, "burner.txt")},
+			{Name: "internal", Path: filepath.Join(dir, "internal")},
+			{Name: "extra", Path: filepath.Join(dir, "extra-*.lst")},
+		}}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 4)
+
+		So(emails.Provenance("mailinator.com"), ShouldResemble, []string{"burner", "internal"})
+		So(emails.Provenance("example.org"), ShouldResemble, []string{"internal"})
+		So(emails.Provenance("wiki.8191.at"), ShouldResemble, []string{"extra"})
+		So(emails.Provenance("gmail.com"), ShouldBeEmpty)
+
+		origin, ok := emails.Index().Origin("mailinator.com")
+		So(ok, ShouldBeTrue)
+		So(origin, ShouldResemble, ListFile{Source: "burner", Path: filepath.Join(dir, "burner.txt"), Format: FormatText})
+		So(emails.Index().Line("mailinator.com"), ShouldEqual, 1)
+		So(len(emails.Index().Files()), ShouldEqual, 4)
+	})
+
+	Convey("Files of a source may be in any supported format", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("# burners\r\nMailinator.com  \r\n\r\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "b.csv"), []byte("domain,reason\nguerrillamail.com,burner\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "c.json"), []byte(`["wiki.8191.at"]`), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "d.hosts"), []byte("127.0.0.1 localhost\n0.0.0.0 example.org\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "burner", Path: dir}}}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.GetAll(), ShouldResemble, []string{"mailinator.com", "guerrillamail.com", "wiki.8191.at", "example.org"})
+		So(emails.Index().Line("mailinator.com"), ShouldEqual, 2)
+
+		origin, _ := emails.Index().Origin("example.org")
+		So(origin.Format, ShouldEqual, FormatHosts)
+	})
+
+	Convey("Allowlist sources are loaded alongside the list", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(ioutil.WriteFile(filepath.Join(dir, "burner.txt"), []byte("mailinator.com\nguerrillamail.com\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "allow.txt"), []byte("guerrillamail.com\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{
+			Sources:      []ListSource{{Name: "burner", Path: filepath.Join(dir, "burner.txt")}},
+			AllowSources: []ListSource{{Name: "partners", Path: filepath.Join(dir, "allow.txt")}},
+		}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Index().Allowlist().Len(), ShouldEqual, 1)
+		So(emails.Index().Allowlist().Provenance("guerrillamail.com"), ShouldResemble, []string{"partners"})
+
+		So(emails.IsOK("john@guerrillamail.com"), ShouldBeTrue)
+		So(emails.IsOK("john@mailinator.com"), ShouldBeFalse)
+
+		version := emails.Index().Version()
+		emails.AllowSources = nil
+		So(emails.Load(), ShouldBeNil)
+		So(emails.IsOK("john@guerrillamail.com"), ShouldBeFalse)
+		So(emails.Index().Version(), ShouldNotEqual, version)
+	})
+
+	Convey("Failure of any source keeps previous list serving", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(ioutil.WriteFile(filepath.Join(dir, "burner.txt"), []byte("mailinator.com\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "burner", Path: filepath.Join(dir, "burner.txt")}}}
+		So(emails.Load(), ShouldBeNil)
+
+		emails.Sources = append(emails.Sources, ListSource{Name: "internal", Path: filepath.Join(dir, "*.missing")})
+		So(emails.Load(), ShouldNotBeNil)
+		So(emails.DomainExists("mailinator.com"), ShouldBeTrue)
+	})
+}
+
+func TestDisposableEmailsReload(t *testing.T) {
+	Convey("Failed reload keeps previous list serving", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		source := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(source, []byte("mailinator.com\nguerrillamail.com\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "burner", Path: source}}}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 2)
+
+		So(os.Remove(source), ShouldBeNil)
+		So(emails.Load(), ShouldNotBeNil)
+		So(emails.Len(), ShouldEqual, 2)
+		So(emails.DomainExists("mailinator.com"), ShouldBeTrue)
+
+		So(ioutil.WriteFile(source, []byte("wiki.8191.at\n"), 0644), ShouldBeNil)
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 1)
+		So(emails.DomainExists("mailinator.com"), ShouldBeFalse)
+	})
+
+	Convey("Rebuilding list leaves shared allowlist alone while it is read", t, func() {
+		allow := NewDomainIndex([]string{"relay.mailinator.com"}, nil)
+		index := NewDomainIndex([]string{"mailinator.com"}, nil)
+		index.SetAllowlist(allow)
+
+		emails := &DisposableEmails{}
+		emails.Swap(index)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := 0; i < 200; i++ {
+				emails.Build([]string{"mailinator.com", fmt.Sprintf("burner-%d.example.com", i)})
+			}
+		}()
+
+		for i := 0; i < 200; i++ {
+			So(emails.IsOK("john@relay.mailinator.com"), ShouldBeTrue)
+		}
+
+		wg.Wait()
+		So(emails.Index().Allowlist(), ShouldEqual, allow)
+	})
+}
+
+// newBenchmarkEmails - Will build disposable emails list of provided size
+// made out of generated domains.
+func newBenchmarkEmails(size int) *DisposableEmails {
+	domains := make([]string, size)
+	for i := range domains {
+		domains[i] = fmt.Sprintf("burner-%d.example.com", i)
+	}
+
+	emails := &DisposableEmails{}
+	emails.Build(domains)
+	return emails
+}
+
+func benchmarkIsOK(b *testing.B, size int) {
+	emails := newBenchmarkEmails(size)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if !emails.IsOK("nevio.vesic@gmail.com") {
+			b.Fatal("gmail.com should not be listed")
+		}
+	}
+}
+
+func benchmarkDomainExists(b *testing.B, size int) {
+	emails := newBenchmarkEmails(size)
+	last := fmt.Sprintf("burner-%d.example.com", size-1)
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		if !emails.DomainExists(last) {
+			b.Fatalf("%s should be listed", last)
+		}
+	}
+}
+
+func BenchmarkIsOK1K(b *testing.B)   { benchmarkIsOK(b, 1000) }
+func BenchmarkIsOK100K(b *testing.B) { benchmarkIsOK(b, 100000) }
+func BenchmarkIsOK1M(b *testing.B)   { benchmarkIsOK(b, 1000000) }
+func BenchmarkIsOK5M(b *testing.B)   { benchmarkIsOK(b, 5000000) }
+
+func BenchmarkDomainExists1K(b *testing.B)   { benchmarkDomainExists(b, 1000) }
+func BenchmarkDomainExists100K(b *testing.B) { benchmarkDomainExists(b, 100000) }
+func BenchmarkDomainExists1M(b *testing.B)   { benchmarkDomainExists(b, 1000000) }
+func BenchmarkDomainExists5M(b *testing.B)   { benchmarkDomainExists(b, 5000000) }
+
+func TestDisposableEmailsFallback(t *testing.T) {
+	Convey("Embedded list serves when no source can be loaded", t, func() {
+		emails := &DisposableEmails{
+			Sources:          []ListSource{{Name: "burner", Path: "/nonexistent/emails.txt"}},
+			EmbeddedFallback: true,
+		}
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Loaded(), ShouldBeTrue)
+		So(emails.ServingFallback(), ShouldBeTrue)
+		So(emails.Len(), ShouldBeGreaterThan, 0)
+		So(emails.IsOK("john@mailinator.com"), ShouldBeFalse)
+		So(emails.Provenance("mailinator.com"), ShouldResemble, []string{EmbeddedSourceName})
+
+		loadErr, _ := emails.LoadError()
+		So(loadErr, ShouldNotBeEmpty)
+		So(EmbeddedListDate(), ShouldNotBeEmpty)
+	})
+
+	Convey("Fallback is replaced once sources load and never replaces a loaded list", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		source := filepath.Join(dir, "emails.txt")
+		emails := &DisposableEmails{
+			Sources:          []ListSource{{Name: "burner", Path: source}},
+			EmbeddedFallback: true,
+		}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.ServingFallback(), ShouldBeTrue)
+
+		So(ioutil.WriteFile(source, []byte("example.org\n"), 0644), ShouldBeNil)
+		So(emails.Load(), ShouldBeNil)
+		So(emails.ServingFallback(), ShouldBeFalse)
+		So(emails.Len(), ShouldEqual, 1)
+
+		So(os.Remove(source), ShouldBeNil)
+		So(emails.Load(), ShouldNotBeNil)
+		So(emails.ServingFallback(), ShouldBeFalse)
+		So(emails.DomainExists("example.org"), ShouldBeTrue)
+	})
+
+	Convey("Without fallback failed first load serves nothing", t, func() {
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "burner", Path: "/nonexistent/emails.txt"}}}
+
+		So(emails.Load(), ShouldNotBeNil)
+		So(emails.Loaded(), ShouldBeFalse)
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	_ "embed"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// EmbeddedSourceName - Source name entries of the embedded list are reported
+// under.
+const EmbeddedSourceName = "embedded"
+
+// embeddedList - List compiled into the binary. Checked in is a small seed of
+// well known burner domains that is no snapshot of any upstream commit, its
+// date and commit are "unknown". Run `make fallback BURNER_REF=<commit>` before
+// building to replace it with the burner list at that commit along with the
+// commit and its date.
+//
+//go:embed assets/emails.txt
+var embeddedList []byte
+
+//go:embed assets/emails.date
+var embeddedListDate string
+
+//go:embed assets/emails.commit
+var embeddedListCommit string
+
+// EmbeddedListDate - Will return date of burner list commit embedded list was
+// taken from
+func EmbeddedListDate() string {
+	return strings.TrimSpace(embeddedListDate)
+}
+
+// EmbeddedListCommit - Will return burner list commit embedded list was taken
+// from
+func EmbeddedListCommit() string {
+	return strings.TrimSpace(embeddedListCommit)
+}
+
+// NewEmbeddedIndex - Will build index out of embedded list
+func NewEmbeddedIndex(suffixes *PublicSuffixList) *DomainIndex {
+	index := NewDomainIndex(nil, suffixes)
+
+	if err := addListData(index, ListFile{Source: EmbeddedSourceName, Path: "assets/emails.txt"}, embeddedList, DefaultMaxFetchSize); err != nil {
+		log.Errorf("[embedded] Could not load embedded list due to (err: %s)", err)
+	}
+
+	return index
+}
@@ -10,6 +10,13 @@ const (
 	ErrorPageNotFound        = "Requested page is not found."
 	ErrorJSONParseError      = "Make sure to provide valid JSON. Could not parse JSON request body."
 	ErrorDomainNotPermitted  = "Domain address is not permitted!"
+	ErrorServiceNotReady     = "Service is not ready yet. Disposable emails list is still loading."
+	ErrorBatchTooLarge       = "Too many email addresses provided in a single batch."
+	ErrorInvalidDomain       = "Invalid domain provided."
+	ErrorSnapshotNotFound    = "Requested list snapshot is not found."
+	ErrorInvalidTimestamp    = "Invalid timestamp provided. Expected RFC 3339 timestamp."
+	ErrorDomainConfusable    = "Domain address is a lookalike and is not permitted!"
+	ErrorDomainTypo          = "Domain address seems to be mistyped."
 )
 
 const (
@@ -18,4 +25,11 @@ const (
 	TypePageNotFound        = "E_PAGE_NOT_FOUND"
 	TypeJSONParseError      = "E_JSON_PARSE_ERROR"
 	TypeDomainNotPermitted  = "E_DOMAIN_NOT_PERMITTED"
+	TypeServiceNotReady     = "E_SERVICE_NOT_READY"
+	TypeBatchTooLarge       = "E_BATCH_TOO_LARGE"
+	TypeInvalidDomain       = "E_INVALID_DOMAIN"
+	TypeSnapshotNotFound    = "E_SNAPSHOT_NOT_FOUND"
+	TypeInvalidTimestamp    = "E_INVALID_TIMESTAMP"
+	TypeDomainConfusable    = "E_DOMAIN_CONFUSABLE"
+	TypeDomainTypo          = "E_DOMAIN_TYPO"
 )
@@ -0,0 +1,65 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	disposable "github.com/0x19/disposable/protos"
+)
+
+const (
+	CheckSyntax     = "syntax"
+	CheckAllowlist  = "allowlist"
+	CheckBlocklist  = "blocklist"
+	CheckConfusable = "confusable"
+	CheckTypo       = "typo"
+)
+
+const (
+	ListDisposable = "disposable"
+	ListAllowlist  = "allowlist"
+)
+
+const (
+	RuleAllowlistOverride = "allowlist_override"
+)
+
+// NewExplanation - Will return empty explanation for provided index when
+// explain mode is requested and nil otherwise.
+func NewExplanation(explain bool, index *DomainIndex) *disposable.DisposableExplanation {
+	if !explain {
+		return nil
+	}
+
+	return &disposable.DisposableExplanation{ListVersion: index.Version()}
+}
+
+// AddCheck - Will record check that ran. Nil explanation (explain mode off)
+// is left alone.
+func AddCheck(e *disposable.DisposableExplanation, check *disposable.DisposableCheck) {
+	if e == nil {
+		return
+	}
+
+	e.Checks = append(e.Checks, check)
+}
+
+// NewMatchCheck - Will return check that matched provided entry of the index
+// along with file and line entry was read from.
+func NewMatchCheck(name, list string, index *DomainIndex, entry string, passed bool) *disposable.DisposableCheck {
+	check := &disposable.DisposableCheck{
+		Name:          name,
+		Passed:        passed,
+		List:          list,
+		MatchedDomain: entry,
+		Line:          int32(index.Line(entry)),
+	}
+
+	if origin, ok := index.Origin(entry); ok {
+		check.Source = origin.Source
+		check.File = origin.Path
+	}
+
+	return check
+}
@@ -0,0 +1,401 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+)
+
+const (
+	FormatText  = "text"
+	FormatCSV   = "csv"
+	FormatJSON  = "json"
+	FormatHosts = "hosts"
+)
+
+// MaxListLineLength - Longer lines of text based list files fail the load
+const MaxListLineLength = 1024 * 1024
+
+// hostsIgnored - Names every hosts file carries that are never list entries
+var hostsIgnored = map[string]bool{
+	"localhost":             true,
+	"localhost.localdomain": true,
+	"local":                 true,
+	"broadcasthost":         true,
+	"ip6-localhost":         true,
+	"ip6-loopback":          true,
+	"ip6-localnet":          true,
+	"ip6-mcastprefix":       true,
+	"ip6-allnodes":          true,
+	"ip6-allrouters":        true,
+	"ip6-allhosts":          true,
+	"0.0.0.0":               true,
+}
+
+// ListEntry - Single raw entry parsed out of a list file along with the line
+// it is on, 1 based.
+type ListEntry struct {
+	Domain string
+	Line   int
+}
+
+// ParseList - Will parse list file of provided name in any of the supported
+// formats: plaintext with # comments, CSV with a domain column, JSON array or
+// object and hosts file. Gzip compressed files are decompressed first, up to
+// DefaultMaxFetchSize bytes. Format is picked by file extension and falls back
+// to sniffing file contents.
+func ParseList(name string, data []byte) ([]ListEntry, string, error) {
+	return ParseListLimited(name, data, DefaultMaxFetchSize)
+}
+
+// ParseListLimited - Will parse list file the way ParseList does failing when
+// gzip compressed file decompresses to more than maxSize bytes
+func ParseListLimited(name string, data []byte, maxSize int64) ([]ListEntry, string, error) {
+	name = strings.ToLower(name)
+	if q := strings.IndexAny(name, "?#"); q >= 0 {
+		name = name[:q]
+	}
+
+	if strings.HasSuffix(name, ".gz") || bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, "", err
+		}
+		defer gz.Close()
+
+		if data, err = readBody(gz, maxSize); err != nil {
+			return nil, "", fmt.Errorf("could not decompress due to (err: %s)", err)
+		}
+
+		name = strings.TrimSuffix(name, ".gz")
+	}
+
+	format := DetectListFormat(name, data)
+
+	var entries []ListEntry
+	var err error
+
+	switch format {
+	case FormatCSV:
+		entries, err = parseCSVList(data)
+	case FormatJSON:
+		entries, err = parseJSONList(data)
+	case FormatHosts:
+		entries, err = parseHostsList(data)
+	default:
+		entries, err = parseTextList(data)
+	}
+
+	return entries, format, err
+}
+
+// DetectListFormat - Will detect format of list file by its extension and
+// (for unknown extensions) by its contents. Contents are only taken for JSON,
+// hosts or CSV when all of them parse in that format, anything else is
+// plaintext so that glob and RE2 pattern entries are never mistaken for them.
+func DetectListFormat(name string, data []byte) string {
+	switch ext := path.Ext(name); {
+	case ext == ".csv":
+		return FormatCSV
+	case ext == ".json":
+		return FormatJSON
+	case ext == ".hosts" || path.Base(name) == "hosts":
+		return FormatHosts
+	case ext == ".txt" || ext == ".lst" || ext == ".list":
+		return FormatText
+	}
+
+	if trimmed := bytes.TrimSpace(data); (bytes.HasPrefix(trimmed, []byte("[")) || bytes.HasPrefix(trimmed, []byte("{"))) && json.Valid(trimmed) {
+		return FormatJSON
+	}
+
+	lines, err := listLines(data)
+	if err != nil {
+		return FormatText
+	}
+
+	switch {
+	case sniffLines(lines, isHostsLine):
+		return FormatHosts
+	case sniffCSV(lines):
+		return FormatCSV
+	}
+
+	return FormatText
+}
+
+// sniffLines - Will check if there is at least one non blank line and every
+// one of them is accepted by fn
+func sniffLines(lines []string, fn func(line string) bool) bool {
+	found := false
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		if !fn(line) {
+			return false
+		}
+
+		found = true
+	}
+
+	return found
+}
+
+// isHostsLine - Will check if provided line is "<ip> <name> [<name>...]"
+func isHostsLine(line string) bool {
+	fields := strings.Fields(line)
+	return len(fields) > 1 && net.ParseIP(fields[0]) != nil
+}
+
+// sniffCSV - Will check if every non blank line is a CSV record of the same
+// number of fields, more than one, none of them starting with a pattern entry.
+func sniffCSV(lines []string) bool {
+	fields := 0
+
+	return sniffLines(lines, func(line string) bool {
+		if strings.HasPrefix(line, "/") {
+			return false
+		}
+
+		r := csv.NewReader(strings.NewReader(line))
+		r.TrimLeadingSpace = true
+
+		record, err := r.Read()
+		if err != nil || len(record) < 2 || (fields > 0 && len(record) != fields) || IsPattern(record[0]) {
+			return false
+		}
+
+		fields = len(record)
+		return true
+	})
+}
+
+// listLines - Will return lines of the list file with comments and
+// surrounding whitespace (CR included) stripped. Every line stays in place so
+// that index plus one is its line number, blank and comment lines end up
+// empty. Lines longer than MaxListLineLength fail the whole file.
+func listLines(data []byte) ([]string, error) {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), MaxListLineLength)
+
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimSpace(stripComment(scanner.Text())))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read (line: %d) due to (err: %s)", len(lines)+1, err)
+	}
+
+	return lines, nil
+}
+
+// stripComment - Will strip full line comment or trailing comment, that is #
+// preceded by whitespace. Hashes within entries, such as in CSV fields or
+// patterns, are kept.
+func stripComment(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return ""
+	}
+
+	for i := 1; i < len(line); i++ {
+		if line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i]
+		}
+	}
+
+	return line
+}
+
+// parseTextList - One domain per line. Anything after the first whitespace
+// delimited token is ignored.
+func parseTextList(data []byte) ([]ListEntry, error) {
+	lines, err := listLines(data)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ListEntry{}
+
+	for i, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 0 {
+			entries = append(entries, ListEntry{Domain: fields[0], Line: i + 1})
+		}
+	}
+
+	return entries, nil
+}
+
+// parseHostsList - Hosts file style "<ip> <name> [<name>...]" lines. Every
+// name but the well known local ones is an entry.
+func parseHostsList(data []byte) ([]ListEntry, error) {
+	lines, err := listLines(data)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ListEntry{}
+
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+
+		for _, name := range fields[1:] {
+			if !hostsIgnored[strings.ToLower(name)] {
+				entries = append(entries, ListEntry{Domain: name, Line: i + 1})
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// parseCSVList - Domains are taken from the column named "domain" if the
+// first row is a header carrying it, otherwise from the first column.
+func parseCSVList(data []byte) ([]ListEntry, error) {
+	lines, err := listLines(data)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []ListEntry{}
+	column := -1
+
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		r := csv.NewReader(strings.NewReader(line))
+		r.TrimLeadingSpace = true
+
+		record, err := r.Read()
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv on (line: %d) due to (err: %s)", i+1, err)
+		}
+
+		if column < 0 {
+			column = 0
+
+			if header := csvDomainColumn(record); header >= 0 {
+				column = header
+				continue
+			}
+		}
+
+		if column < len(record) {
+			entries = append(entries, ListEntry{Domain: strings.TrimSpace(record[column]), Line: i + 1})
+		}
+	}
+
+	return entries, nil
+}
+
+// csvDomainColumn - Will return index of the domain column in provided
+// header row or -1 if row is not a header.
+func csvDomainColumn(record []string) int {
+	for i, field := range record {
+		if strings.EqualFold(strings.TrimSpace(field), "domain") {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// parseJSONList - Supported documents are array of domains, array of objects
+// with "domain" key, object with "domains" array or object keyed by domains.
+func parseJSONList(data []byte) ([]ListEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	entries := []ListEntry{}
+
+	line := func() int {
+		return 1 + bytes.Count(data[:dec.InputOffset()], []byte("\n"))
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		return parseJSONArray(dec, line, entries)
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			domain, _ := key.(string)
+
+			if domain == "domains" {
+				if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+					return nil, fmt.Errorf("expected array of domains on (line: %d)", line())
+				}
+
+				if entries, err = parseJSONArray(dec, line, entries); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+
+			entries = append(entries, ListEntry{Domain: domain, Line: line()})
+
+			var value json.RawMessage
+			if err := dec.Decode(&value); err != nil {
+				return nil, err
+			}
+		}
+
+		return entries, nil
+	}
+
+	return nil, fmt.Errorf("expected json array or object, got (token: %v)", tok)
+}
+
+// parseJSONArray - Will parse array elements up to and including its closing
+// bracket. Elements are either domains or objects with "domain" key.
+func parseJSONArray(dec *json.Decoder, line func() int, entries []ListEntry) ([]ListEntry, error) {
+	for dec.More() {
+		var element json.RawMessage
+		if err := dec.Decode(&element); err != nil {
+			return nil, err
+		}
+
+		var domain string
+		if err := json.Unmarshal(element, &domain); err != nil {
+			var object struct {
+				Domain string `json:"domain"`
+			}
+
+			if err := json.Unmarshal(element, &object); err != nil {
+				return nil, fmt.Errorf("expected domain or object with domain on (line: %d)", line())
+			}
+
+			domain = object.Domain
+		}
+
+		entries = append(entries, ListEntry{Domain: domain, Line: line()})
+	}
+
+	_, err := dec.Token()
+	return entries, err
+}
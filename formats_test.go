@@ -0,0 +1,161 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// domainsOf - Will return domains of parsed entries
+func domainsOf(entries []ListEntry) []string {
+	domains := []string{}
+	for _, entry := range entries {
+		domains = append(domains, entry.Domain)
+	}
+	return domains
+}
+
+func TestParseList(t *testing.T) {
+	Convey("Plaintext lists skip comments, blank lines and CRLF endings", t, func() {
+		entries, format, err := ParseList("emails.txt", []byte("# burner domains\r\nmailinator.com  \r\n\r\n  =guerrillamail.com # exact\r\nwiki.8191.at trailing junk\n"))
+
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatText)
+		So(entries, ShouldResemble, []ListEntry{
+			{Domain: "mailinator.com", Line: 2},
+			{Domain: "=guerrillamail.com", Line: 4},
+			{Domain: "wiki.8191.at", Line: 5},
+		})
+	})
+
+	Convey("Only full line and whitespace preceded comments are stripped", t, func() {
+		entries, _, err := ParseList("emails.txt", []byte("  # comment\nmail#box.com\nmailinator.com\t# burner\n"))
+		So(err, ShouldBeNil)
+		So(domainsOf(entries), ShouldResemble, []string{"mail#box.com", "mailinator.com"})
+
+		entries, _, err = ParseList("list.csv", []byte("domain,reason\nmailinator.com,burner #1\nguerrillamail.com,#2\n"))
+		So(err, ShouldBeNil)
+		So(domainsOf(entries), ShouldResemble, []string{"mailinator.com", "guerrillamail.com"})
+	})
+
+	Convey("Lines over the length limit fail the whole list", t, func() {
+		data := "mailinator.com\n" + strings.Repeat("a", MaxListLineLength+1) + "\nguerrillamail.com\n"
+
+		for _, name := range []string{"emails.txt", "list.csv", "hosts"} {
+			_, _, err := ParseList(name, []byte(data))
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "line: 2")
+		}
+	})
+
+	Convey("CSV lists use domain column of the header or the first column", t, func() {
+		entries, format, err := ParseList("list.csv", []byte("added,Domain,reason\n2016-01-01, mailinator.com ,burner\n# removed\n2016-01-02,\"guerrillamail.com\",burner\n"))
+
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatCSV)
+		So(entries, ShouldResemble, []ListEntry{
+			{Domain: "mailinator.com", Line: 2},
+			{Domain: "guerrillamail.com", Line: 4},
+		})
+
+		entries, _, err = ParseList("list.csv", []byte("mailinator.com,burner\nguerrillamail.com,burner\n"))
+		So(err, ShouldBeNil)
+		So(domainsOf(entries), ShouldResemble, []string{"mailinator.com", "guerrillamail.com"})
+	})
+
+	Convey("JSON lists may be arrays or objects", t, func() {
+		entries, format, err := ParseList("list.json", []byte("[\n  \"mailinator.com\",\n  {\"domain\": \"guerrillamail.com\"}\n]"))
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatJSON)
+		So(entries, ShouldResemble, []ListEntry{
+			{Domain: "mailinator.com", Line: 2},
+			{Domain: "guerrillamail.com", Line: 3},
+		})
+
+		entries, _, err = ParseList("list.json", []byte(`{"domains": ["mailinator.com", "guerrillamail.com"]}`))
+		So(err, ShouldBeNil)
+		So(domainsOf(entries), ShouldResemble, []string{"mailinator.com", "guerrillamail.com"})
+
+		entries, _, err = ParseList("list.json", []byte("{\n\"mailinator.com\": true,\n\"guerrillamail.com\": {\"since\": 2016}\n}"))
+		So(err, ShouldBeNil)
+		So(entries, ShouldResemble, []ListEntry{
+			{Domain: "mailinator.com", Line: 2},
+			{Domain: "guerrillamail.com", Line: 3},
+		})
+
+		_, _, err = ParseList("list.json", []byte(`[1, 2]`))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Hosts files skip local names", t, func() {
+		entries, format, err := ParseList("blocked", []byte("127.0.0.1 localhost\n0.0.0.0 mailinator.com www.mailinator.com # burner\n::1 ip6-localhost\n"))
+
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatHosts)
+		So(domainsOf(entries), ShouldResemble, []string{"mailinator.com", "www.mailinator.com"})
+		So(entries[1].Line, ShouldEqual, 2)
+	})
+
+	Convey("Format is sniffed out of contents for unknown extensions", t, func() {
+		So(DetectListFormat("list", []byte("  [\"mailinator.com\"]")), ShouldEqual, FormatJSON)
+		So(DetectListFormat("list", []byte("# comment\n\nmailinator.com,burner\n")), ShouldEqual, FormatCSV)
+		So(DetectListFormat("list", []byte("mailinator.com\n")), ShouldEqual, FormatText)
+		So(DetectListFormat("hosts", []byte("mailinator.com\n")), ShouldEqual, FormatHosts)
+		So(DetectListFormat("list", []byte("0.0.0.0 mailinator.com\n127.0.0.1 localhost\n")), ShouldEqual, FormatHosts)
+	})
+
+	Convey("Contents only partly parsing in a format are plaintext", t, func() {
+		So(DetectListFormat("list", []byte("[a-z]*.tk\nmailinator.com\n")), ShouldEqual, FormatText)
+		So(DetectListFormat("list", []byte("/^mail[0-9]{1,3}\\.tk$/\n")), ShouldEqual, FormatText)
+		So(DetectListFormat("list", []byte("mailinator.com,burner\nguerrillamail.com\n")), ShouldEqual, FormatText)
+		So(DetectListFormat("list", []byte("0.0.0.0 mailinator.com\nguerrillamail.com\n")), ShouldEqual, FormatText)
+		So(DetectListFormat("emails.txt", []byte("[\"mailinator.com\"]")), ShouldEqual, FormatText)
+
+		entries, format, err := ParseList("patterns", []byte("[a-z]*.tk\n/^mail[0-9]{1,3}\\.tk$/\n"))
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatText)
+		So(domainsOf(entries), ShouldResemble, []string{"[a-z]*.tk", `/^mail[0-9]{1,3}\.tk$/`})
+	})
+
+	Convey("Gzip compressed lists are detected by extension or contents", t, func() {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write([]byte(`["mailinator.com"]`))
+		gz.Close()
+
+		entries, format, err := ParseList("https://example.com/list.json.gz?v=1", buf.Bytes())
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatJSON)
+		So(domainsOf(entries), ShouldResemble, []string{"mailinator.com"})
+
+		entries, format, err = ParseList("list", buf.Bytes())
+		So(err, ShouldBeNil)
+		So(format, ShouldEqual, FormatJSON)
+		So(domainsOf(entries), ShouldResemble, []string{"mailinator.com"})
+
+		_, _, err = ParseList("list.gz", []byte("not gzip"))
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Gzip compressed lists decompressing past the limit are rejected", t, func() {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		gz.Write(bytes.Repeat([]byte("mailinator.com\n"), 1000))
+		gz.Close()
+
+		_, _, err := ParseListLimited("list.txt.gz", buf.Bytes(), 1024)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "max_size: 1024")
+
+		entries, _, err := ParseListLimited("list.txt.gz", buf.Bytes(), 15000)
+		So(err, ShouldBeNil)
+		So(len(entries), ShouldEqual, 1000)
+	})
+}
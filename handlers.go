@@ -5,6 +5,12 @@
 package main
 
 import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
 	disposable "github.com/0x19/disposable/protos"
 	log "github.com/Sirupsen/logrus"
 	"golang.org/x/net/context"
@@ -14,24 +20,445 @@ import (
 func (s *Service) Verify(c context.Context, req *disposable.DisposableRequest) (*disposable.DisposableResponse, error) {
 	log.Infof("[verify] Starting email verification process (req: %v)", req)
 
-	if err := ValidateEmail(req.Email); err != nil {
-		log.Errorf("[verify] Could not validate email address due to (err: %s)", err)
-		return err, nil
-	}
-
-	if ok := s.DisposableEmails.IsOK(req.Email); !ok {
-		log.Errorf("[verify] Seems like provided (email: %s) is illegal. Returning error now...", req.Email)
+	if !s.DisposableEmails.Loaded() {
+		log.Warnf("[verify] Refusing to verify (email: %s) as disposable emails are not loaded yet", req.Email)
 		return &disposable.DisposableResponse{
 			Status:    false,
 			RequestId: GetUUID(),
-			Error:     disposable.NewError(ErrorDomainNotPermitted, TypeDomainNotPermitted, nil),
+			Error:     disposable.NewError(ErrorServiceNotReady, TypeServiceNotReady, nil),
 		}, nil
 	}
 
+	index, resp := s.verifyIndex(req)
+	if resp != nil {
+		return resp, nil
+	}
+
+	return s.verify(req, index), nil
+}
+
+// verify - Will verify email address against provided list. Verify and
+// VerifyBatch pick the list so that every address of a batch is verified
+// against the same one.
+func (s *Service) verify(req *disposable.DisposableRequest, index *DomainIndex) *disposable.DisposableResponse {
+	explanation := NewExplanation(req.Explain, index)
+
+	address, err := ValidateEmail(req.Email, s.AddressOptions())
+	if err != nil {
+		log.Errorf("[verify] Could not validate email address due to (err: %s)", err.Error)
+		AddCheck(explanation, &disposable.DisposableCheck{Name: CheckSyntax, Rule: err.Error.Info["rule"]})
+		err.Explanation = explanation
+		err.ListVersion = index.Version()
+		return err
+	}
+
+	AddCheck(explanation, &disposable.DisposableCheck{Name: CheckSyntax, Passed: true})
+
+	canonical := s.ProviderRules.Normalize(address).Address
+	domain := NormalizeDomain(address.Domain)
+	registrable := index.Suffixes().RegistrableDomain(domain)
+
+	allowed, override := index.Allow(domain)
+
+	if index.Allowlist() != nil {
+		AddCheck(explanation, NewMatchCheck(CheckAllowlist, ListAllowlist, index.Allowlist(), allowed, true))
+	}
+
+	didYouMean, confident := s.suggest(req, explanation, index, address, domain, override)
+	if didYouMean != "" && confident && s.StrictSuggestions {
+		log.Errorf("[verify] Rejecting mistyped (email: %s) in strict mode - (did_you_mean: %s)", req.Email, didYouMean)
+		return &disposable.DisposableResponse{
+			Status:            false,
+			RequestId:         GetUUID(),
+			Error:             disposable.NewError(ErrorDomainTypo, TypeDomainTypo, nil),
+			Domain:            domain,
+			RegistrableDomain: registrable,
+			Explanation:       explanation,
+			DidYouMean:        didYouMean,
+			Canonical:         canonical,
+			ListVersion:       index.Version(),
+		}
+	}
+
+	if resp := s.verifyConfusable(req, explanation, index, domain, registrable, override); resp != nil {
+		resp.DidYouMean = didYouMean
+		resp.Canonical = canonical
+		return resp
+	}
+
+	entry, listed := index.Match(domain)
+	sources := index.Provenance(entry)
+
+	if listed && !override {
+		log.Errorf("[verify] Seems like provided (email: %s) is illegal as it matches (entry: %s) from (sources: %v). Returning error now...", req.Email, entry, sources)
+		AddCheck(explanation, NewMatchCheck(CheckBlocklist, ListDisposable, index, entry, false))
+
+		return &disposable.DisposableResponse{
+			Status:            false,
+			RequestId:         GetUUID(),
+			Error:             disposable.NewError(ErrorDomainNotPermitted, TypeDomainNotPermitted, nil),
+			MatchedDomain:     entry,
+			MatchedRule:       MatchRule(domain, entry),
+			Domain:            domain,
+			RegistrableDomain: registrable,
+			Explanation:       explanation,
+			Sources:           sources,
+			DidYouMean:        didYouMean,
+			Canonical:         canonical,
+			ListVersion:       index.Version(),
+		}
+	}
+
+	if listed {
+		log.Warnf("[verify] Allowlist (entry: %s) overrides (entry: %s) from (sources: %v) for (email: %s)", allowed, entry, sources, req.Email)
+
+		check := NewMatchCheck(CheckBlocklist, ListDisposable, index, entry, true)
+		check.Rule = RuleAllowlistOverride
+		AddCheck(explanation, check)
+	} else {
+		AddCheck(explanation, &disposable.DisposableCheck{Name: CheckBlocklist, List: ListDisposable, Passed: true})
+	}
+
 	log.Infof("[verify] Domain verification passed for (email: %s)", req.Email)
 
 	return &disposable.DisposableResponse{
+		Status:            true,
+		RequestId:         GetUUID(),
+		Domain:            domain,
+		RegistrableDomain: registrable,
+		Explanation:       explanation,
+		MatchedDomain:     entry,
+		MatchedRule:       MatchRule(domain, entry),
+		Sources:           sources,
+		Override:          listed,
+		AllowedDomain:     allowed,
+		DidYouMean:        didYouMean,
+		Canonical:         canonical,
+		ListVersion:       index.Version(),
+	}
+}
+
+// suggest - Will return address provided one was most likely mistyped from or
+// empty string if there is no suggestion, along with whether the typo is
+// certain enough to reject the address in strict mode. Allowlisted and listed
+// domains are known to be real and are never corrected.
+func (s *Service) suggest(req *disposable.DisposableRequest, explanation *disposable.DisposableExplanation, index *DomainIndex, address *Address, domain string, override bool) (string, bool) {
+	if s.Suggester == nil || override {
+		return "", false
+	}
+
+	if _, listed := index.Match(domain); listed {
+		return "", false
+	}
+
+	suggestion, ok := s.Suggester.Suggest(domain, index.Suffixes())
+	if !ok {
+		AddCheck(explanation, &disposable.DisposableCheck{Name: CheckTypo, Passed: true})
+		return "", false
+	}
+
+	log.Warnf("[verify] Seems like (email: %s) is mistyped - (domain: %s) - (did_you_mean: %s) - (confident: %t)", req.Email, domain, suggestion.Domain, suggestion.Confident)
+	AddCheck(explanation, &disposable.DisposableCheck{Name: CheckTypo, Passed: !(s.StrictSuggestions && suggestion.Confident), MatchedDomain: suggestion.Domain})
+
+	return address.LocalPart + "@" + suggestion.Domain, suggestion.Confident
+}
+
+// verifyConfusable - Will return failed response when provided domain looks
+// like a protected domain or mixes scripts. Domains matching allowlist are
+// never reported.
+func (s *Service) verifyConfusable(req *disposable.DisposableRequest, explanation *disposable.DisposableExplanation, index *DomainIndex, domain, registrable string, override bool) *disposable.DisposableResponse {
+	if s.Confusables == nil || override {
+		return nil
+	}
+
+	checked := registrable
+	if checked == "" {
+		checked = domain
+	}
+
+	match, confusable := s.Confusables.Check(checked)
+	if !confusable {
+		AddCheck(explanation, &disposable.DisposableCheck{Name: CheckConfusable, Passed: true})
+		return nil
+	}
+
+	log.Errorf("[verify] Seems like provided (email: %s) is a lookalike - (reason: %s) - (protected: %s) - (scripts: %v). Returning error now...", req.Email, match.Reason, match.Protected, match.Scripts)
+	AddCheck(explanation, &disposable.DisposableCheck{Name: CheckConfusable, Rule: match.Reason, MatchedDomain: match.Protected})
+
+	resp := &disposable.DisposableResponse{
+		Status:            false,
+		RequestId:         GetUUID(),
+		Error:             disposable.NewError(ErrorDomainConfusable, TypeDomainConfusable, nil),
+		Domain:            domain,
+		RegistrableDomain: registrable,
+		Explanation:       explanation,
+		ListVersion:       index.Version(),
+	}
+
+	resp.Error.Info = map[string]string{"reason": match.Reason}
+
+	if match.Protected != "" {
+		resp.Error.Info["protected"] = match.Protected
+	}
+
+	if len(match.Scripts) > 0 {
+		resp.Error.Info["scripts"] = strings.Join(match.Scripts, ",")
+	}
+
+	return resp
+}
+
+// verifyIndex - Will return list verify request is evaluated against. That is
+// either the list of requested version, the one that was serving at requested
+// time or the one serving now. Failed response is returned when requested list
+// is not kept in history.
+func (s *Service) verifyIndex(req *disposable.DisposableRequest) (*DomainIndex, *disposable.DisposableResponse) {
+	index, err := s.DisposableEmails.IndexFor(req.ListVersion, req.At)
+	if err == nil {
+		return index, nil
+	}
+
+	log.Warnf("[verify] Could not find list of (version: %s) - (at: %s) to verify (email: %s) against due to (err: %s)", req.ListVersion, req.At, req.Email, err)
+
+	if _, ok := err.(*time.ParseError); ok {
+		return nil, &disposable.DisposableResponse{
+			Status:    false,
+			RequestId: GetUUID(),
+			Error:     disposable.NewError(ErrorInvalidTimestamp, TypeInvalidTimestamp, err),
+		}
+	}
+
+	return nil, &disposable.DisposableResponse{
+		Status:    false,
+		RequestId: GetUUID(),
+		Error:     disposable.NewError(ErrorSnapshotNotFound, TypeSnapshotNotFound, err),
+	}
+}
+
+// CheckDomain - Will look provided domain up in disposable emails list
+// without requiring a full email address. Listed domains are not an error,
+// response tells whether domain is listed, which entry matched and which
+// sources it came from. Domains overridden by allowlist are not listed.
+func (s *Service) CheckDomain(c context.Context, req *disposable.DisposableDomainRequest) (*disposable.DisposableDomainResponse, error) {
+	log.Infof("[check_domain] Starting domain check process (req: %v)", req)
+
+	if !s.DisposableEmails.Loaded() {
+		log.Warnf("[check_domain] Refusing to check (domain: %s) as disposable emails are not loaded yet", req.Domain)
+		return &disposable.DisposableDomainResponse{
+			Status:    false,
+			RequestId: GetUUID(),
+			Error:     disposable.NewError(ErrorServiceNotReady, TypeServiceNotReady, nil),
+		}, nil
+	}
+
+	if err := ValidateDomain(req.Domain); err != nil {
+		log.Errorf("[check_domain] Could not validate (domain: %s)", req.Domain)
+		return err, nil
+	}
+
+	index := s.DisposableEmails.Index()
+	domain := NormalizeDomain(req.Domain)
+	entry, listed := index.Match(domain)
+	sources := index.Provenance(entry)
+	allowed, override := index.Allow(domain)
+
+	log.Infof("[check_domain] Checked (domain: %s) - (listed: %t) - (entry: %s) - (sources: %v) - (allowed: %s)", domain, listed, entry, sources, allowed)
+
+	return &disposable.DisposableDomainResponse{
+		Status:            true,
+		RequestId:         GetUUID(),
+		Listed:            listed && !override,
+		Override:          listed && override,
+		AllowedDomain:     allowed,
+		Domain:            domain,
+		RegistrableDomain: index.Suffixes().RegistrableDomain(domain),
+		MatchedDomain:     entry,
+		MatchedRule:       MatchRule(domain, entry),
+		Sources:           sources,
+	}, nil
+}
+
+// Normalize - Will return canonical mailbox of provided email address so that
+// addresses delivered to the same mailbox can be told apart. Lists don't have
+// to be loaded.
+func (s *Service) Normalize(c context.Context, req *disposable.DisposableNormalizeRequest) (*disposable.DisposableNormalizeResponse, error) {
+	log.Infof("[normalize] Starting email normalization process (req: %v)", req)
+
+	address, resp := ValidateEmail(req.Email, s.AddressOptions())
+	if resp != nil {
+		log.Errorf("[normalize] Could not validate email address due to (err: %s)", resp.Error)
+		return &disposable.DisposableNormalizeResponse{
+			Status:    false,
+			RequestId: resp.RequestId,
+			Error:     resp.Error,
+			Email:     req.Email,
+		}, nil
+	}
+
+	canonical := s.ProviderRules.Normalize(address)
+
+	log.Infof("[normalize] Normalized (email: %s) into (canonical: %s) - (provider: %s) - (rules: %v)", req.Email, canonical.Address, canonical.Provider, canonical.Rules)
+
+	return &disposable.DisposableNormalizeResponse{
 		Status:    true,
 		RequestId: GetUUID(),
+		Email:     req.Email,
+		Canonical: canonical.Address,
+		Provider:  canonical.Provider,
+		Rules:     canonical.Rules,
 	}, nil
 }
+
+// VerifyBatch - Will verify every provided email address and return results
+// in the same order. Invalid or illegal addresses fail only their own result,
+// whole batch fails only when it can't be processed at all. Every address is
+// verified against the list serving when the batch started, reloads in the
+// middle of it are not seen.
+func (s *Service) VerifyBatch(c context.Context, req *disposable.DisposableBatchRequest) (*disposable.DisposableBatchResponse, error) {
+	log.Infof("[verify_batch] Starting batch email verification process (emails: %d)", len(req.Emails))
+
+	if !s.DisposableEmails.Loaded() {
+		log.Warnf("[verify_batch] Refusing to verify batch as disposable emails are not loaded yet")
+		return &disposable.DisposableBatchResponse{
+			Status:    false,
+			RequestId: GetUUID(),
+			Error:     disposable.NewError(ErrorServiceNotReady, TypeServiceNotReady, nil),
+		}, nil
+	}
+
+	if maxBatchSize := s.maxBatchSize(); len(req.Emails) > maxBatchSize {
+		log.Errorf("[verify_batch] Batch of (emails: %d) exceeds (max_batch_size: %d)", len(req.Emails), maxBatchSize)
+		return &disposable.DisposableBatchResponse{
+			Status:    false,
+			RequestId: GetUUID(),
+			Error: &disposable.Error{
+				Message: ErrorBatchTooLarge,
+				Type:    TypeBatchTooLarge,
+				Info: map[string]string{
+					"max_batch_size": strconv.Itoa(maxBatchSize),
+				},
+			},
+		}, nil
+	}
+
+	index := s.DisposableEmails.Index()
+	results := make([]*disposable.DisposableResponse, 0, len(req.Emails))
+
+	for _, email := range req.Emails {
+		if err := c.Err(); err != nil {
+			log.Errorf("[verify_batch] Stopping batch verification after (emails: %d) due to (err: %s)", len(results), err)
+			return nil, err
+		}
+
+		results = append(results, s.verify(&disposable.DisposableRequest{Email: email}, index))
+	}
+
+	return &disposable.DisposableBatchResponse{
+		Status:    true,
+		RequestId: GetUUID(),
+		Results:   results,
+	}, nil
+}
+
+// maxBatchSize - Will return maximum number of email addresses accepted by
+// VerifyBatch, DefaultMaxBatchSize unless MaxBatchSize is set
+func (s *Service) maxBatchSize() int {
+	if s.MaxBatchSize <= 0 {
+		return DefaultMaxBatchSize
+	}
+
+	return s.MaxBatchSize
+}
+
+// VerifyStream - Will verify email addresses as they are pushed through the
+// stream and push results back as soon as they complete, each one carrying id
+// of the request it belongs to. Up to StreamConcurrency addresses are verified
+// at once. Slow readers hold up workers which in turn stop receiving so GRPC
+// flow control pushes back to the client. Cancelled stream stops all work.
+func (s *Service) VerifyStream(stream disposable.DisposableService_VerifyStreamServer) error {
+	workers := s.StreamConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	log.Infof("[verify_stream] Starting stream email verification process (concurrency: %d)", workers)
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	requests := make(chan *disposable.DisposableStreamRequest)
+	responses := make(chan *disposable.DisposableStreamResponse)
+	recverr := make(chan error, 1)
+
+	go func() {
+		defer close(requests)
+
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+
+			if err != nil {
+				recverr <- err
+				return
+			}
+
+			select {
+			case requests <- req:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for req := range requests {
+				if ctx.Err() != nil {
+					return
+				}
+
+				resp, _ := s.Verify(ctx, &disposable.DisposableRequest{Email: req.Email})
+
+				select {
+				case responses <- &disposable.DisposableStreamResponse{Id: req.Id, Result: resp}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(responses)
+	}()
+
+	for resp := range responses {
+		if err := stream.Send(resp); err != nil {
+			log.Errorf("[verify_stream] Failed to send (id: %s) result due to (err: %s)", resp.Id, err)
+			return err
+		}
+	}
+
+	select {
+	case err := <-recverr:
+		log.Errorf("[verify_stream] Stream receive failed due to (err: %s)", err)
+		return err
+	default:
+	}
+
+	if err := ctx.Err(); err != nil {
+		log.Warnf("[verify_stream] Stream verification stopped due to (err: %s)", err)
+		return err
+	}
+
+	return nil
+}
@@ -54,11 +54,255 @@ func HandleVerifyEmail(s *Service, w http.ResponseWriter, req *http.Request) {
 	}
 
 	if !accresp.Status {
+		w.WriteHeader(StatusCodeFor(accresp.Error))
+		w.Write(j)
+		return
+	}
+
+	w.Write(j)
+	return
+}
+
+// HandleVerifyBatch -
+func HandleVerifyBatch(s *Service, w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var vreq disposable.DisposableBatchRequest
+
+	if err := DecodeRequestBody(&vreq, req.Body); err != nil {
+		j, derr := json.MarshalIndent(err, "", " ")
+		if derr != nil {
+			log.Errorf("[http_handle_verify_batch] Unable to decode json into disposable batch request due to (err: %s)", derr)
+			http.Error(w, derr.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(j)
+		return
+	}
+
+	log.Infof("[http_handle_verify_batch] Got new batch email verification request (emails: %d)", len(vreq.Emails))
+
+	timeout, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	batchresp, err := s.VerifyBatch(timeout, &vreq)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	j, err := json.MarshalIndent(batchresp, "", " ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !batchresp.Status {
+		w.WriteHeader(StatusCodeFor(batchresp.Error))
+		w.Write(j)
+		return
+	}
+
+	w.Write(j)
+	return
+}
+
+// HandleCheckDomain -
+func HandleCheckDomain(s *Service, w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	vars := mux.Vars(req)
+
+	log.Infof("[http_handle_check_domain] Got new domain check request (domain: %s)", vars["domain"])
+
+	timeout, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	domresp, err := s.CheckDomain(timeout, &disposable.DisposableDomainRequest{Domain: vars["domain"]})
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	j, err := json.MarshalIndent(domresp, "", " ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !domresp.Status {
+		w.WriteHeader(StatusCodeFor(domresp.Error))
+		w.Write(j)
+		return
+	}
+
+	w.Write(j)
+	return
+}
+
+// HandleNormalize - Will return canonical mailbox of email address
+func HandleNormalize(s *Service, w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var nreq disposable.DisposableNormalizeRequest
+
+	if err := DecodeRequestBody(&nreq, req.Body); err != nil {
+		j, derr := json.MarshalIndent(err, "", " ")
+		if derr != nil {
+			log.Errorf("[http_handle_normalize] Unable to decode json into normalize request due to (err: %s)", derr)
+			http.Error(w, derr.Error(), http.StatusInternalServerError)
+			return
+		}
+
 		w.WriteHeader(http.StatusBadRequest)
 		w.Write(j)
 		return
 	}
 
+	log.Infof("[http_handle_normalize] Got new email normalization request (body: %+v)", nreq)
+
+	timeout, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	normresp, err := s.Normalize(timeout, &nreq)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	j, err := json.MarshalIndent(normresp, "", " ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !normresp.Status {
+		w.WriteHeader(StatusCodeFor(normresp.Error))
+		w.Write(j)
+		return
+	}
+
 	w.Write(j)
 	return
 }
+
+// HandleListSnapshots - Will list snapshots of loaded lists, newest first
+func HandleListSnapshots(s *Service, w http.ResponseWriter, req *http.Request) {
+	log.Infof("[http_handle_list_snapshots] Got new list snapshots request")
+	WriteJSON(w, s.DisposableEmails.Snapshots(), http.StatusOK)
+}
+
+// HandleDiffSnapshots - Will diff two snapshots of loaded lists
+func HandleDiffSnapshots(s *Service, w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+
+	log.Infof("[http_handle_diff_snapshots] Got new snapshots diff request (from: %s) - (to: %s)", vars["from"], vars["to"])
+
+	diff, err := s.DisposableEmails.Diff(vars["from"], vars["to"])
+	if err != nil {
+		WriteSnapshotNotFound(w, err)
+		return
+	}
+
+	WriteJSON(w, diff, http.StatusOK)
+}
+
+// HandleRollbackSnapshot - Will roll back to snapshot of provided version
+func HandleRollbackSnapshot(s *Service, w http.ResponseWriter, req *http.Request) {
+	vars := mux.Vars(req)
+
+	log.Warnf("[http_handle_rollback_snapshot] Got new rollback request (version: %s)", vars["version"])
+
+	if _, err := s.DisposableEmails.Rollback(vars["version"]); err != nil {
+		WriteSnapshotNotFound(w, err)
+		return
+	}
+
+	WriteJSON(w, s.DisposableEmails.Snapshots(), http.StatusOK)
+}
+
+// HandleReleaseRollback - Will release rollback and serve the latest loaded
+// list again.
+func HandleReleaseRollback(s *Service, w http.ResponseWriter, req *http.Request) {
+	log.Infof("[http_handle_release_rollback] Got new rollback release request")
+
+	s.DisposableEmails.Release()
+	WriteJSON(w, s.DisposableEmails.Snapshots(), http.StatusOK)
+}
+
+// WriteSnapshotNotFound - Will write snapshot not found error response
+func WriteSnapshotNotFound(w http.ResponseWriter, err error) {
+	resp := &disposable.DisposableResponse{
+		Status:    false,
+		RequestId: GetUUID(),
+		Error:     disposable.NewError(ErrorSnapshotNotFound, TypeSnapshotNotFound, err),
+	}
+
+	WriteJSON(w, resp, StatusCodeFor(resp.Error))
+}
+
+// WriteJSON - Will write provided value as JSON with provided status code
+func WriteJSON(w http.ResponseWriter, v interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+
+	j, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	w.Write(j)
+}
+
+// HandleHealthz - Liveness probe. Responds with 503 when either of GRPC or
+// HTTP listeners is down.
+func HandleHealthz(s *Service, w http.ResponseWriter, req *http.Request) {
+	r := s.HealthReport()
+	WriteHealthReport(w, r, r.Live)
+}
+
+// HandleReadyz - Readiness probe. Responds with 503 until disposable emails
+// list is loaded for the first time, while it is stale or while any of the
+// listeners is down. Embedded fallback list serving instead of it is reported
+// through list_fallback but does not affect readiness.
+func HandleReadyz(s *Service, w http.ResponseWriter, req *http.Request) {
+	r := s.HealthReport()
+	WriteHealthReport(w, r, r.Ready)
+}
+
+// WriteHealthReport - Will write health report as JSON with 200 status code
+// if ok or 503 otherwise.
+func WriteHealthReport(w http.ResponseWriter, r *HealthReport, ok bool) {
+	w.Header().Set("Content-Type", "application/json")
+
+	j, err := json.MarshalIndent(r, "", " ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !ok {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	w.Write(j)
+	return
+}
+
+// StatusCodeFor - Will return HTTP status code matching error of failed response
+func StatusCodeFor(err *disposable.Error) int {
+	if err != nil && err.Type == TypeServiceNotReady {
+		return http.StatusServiceUnavailable
+	}
+
+	if err != nil && err.Type == TypeSnapshotNotFound {
+		return http.StatusNotFound
+	}
+
+	return http.StatusBadRequest
+}
@@ -8,14 +8,35 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
 	disposable "github.com/0x19/disposable/protos"
+	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
+// waitHealthy - Will poll provided health endpoint until it reports success
+// or timeout expires, returning the latest response either way
+func waitHealthy(url string, timeout time.Duration) (*http.Response, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := http.Get(url)
+		if (err == nil && resp.StatusCode == http.StatusOK) || time.Now().After(deadline) {
+			return resp, err
+		}
+
+		if err == nil {
+			resp.Body.Close()
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
 func TestHandleVerifyEmail(t *testing.T) {
 	var service *Service
 	var err error
@@ -26,16 +47,50 @@ func TestHandleVerifyEmail(t *testing.T) {
 	go func(service *Service, err error) {
 		os.Setenv("GRPC_ADDR", fmt.Sprintf(":%d", grpcPort))
 		os.Setenv("HTTP_ADDR", fmt.Sprintf(":%d", httpPort))
+		os.Setenv("ADMIN_USERNAME", "admin")
+		os.Setenv("ADMIN_PASSWORD", "admin123")
 		service, err = NewTestServer()
 	}(service, err)
 
-	time.Sleep(2 * time.Second)
-
 	Convey("Disposable service started successfully", t, func() {
 		So(service, ShouldHaveSameTypeAs, &Service{})
 		So(err, ShouldBeNil)
 	})
 
+	Convey("Service is live once listeners are up", t, func() {
+		resp, err := waitHealthy(fmt.Sprintf("http://localhost:%d/healthz", httpPort), 10*time.Second)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+
+		var hr HealthReport
+		jrerr := DecodeJSONBody(&hr, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(hr.Live, ShouldBeTrue)
+		So(hr.GRPCListening, ShouldBeTrue)
+		So(hr.HTTPListening, ShouldBeTrue)
+	})
+
+	Convey("Service is ready once disposable emails are loaded", t, func() {
+		resp, err := waitHealthy(fmt.Sprintf("http://localhost:%d/readyz", httpPort), 10*time.Second)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+
+		var hr HealthReport
+		jrerr := DecodeJSONBody(&hr, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(hr.Ready, ShouldBeTrue)
+		So(hr.ListLoaded, ShouldBeTrue)
+		So(hr.ListStale, ShouldBeFalse)
+		So(hr.ListDomains, ShouldBeGreaterThan, 0)
+		So(hr.ListLoadedAt, ShouldNotBeNil)
+	})
+
 	Convey("Valid JSON is required", t, func() {
 		req, err := http.NewRequest(
 			"POST",
@@ -182,6 +237,8 @@ func TestHandleVerifyEmail(t *testing.T) {
 		So(dr.Error, ShouldHaveSameTypeAs, &disposable.Error{})
 		So(dr.Error.Message, ShouldEqual, ErrorDomainNotPermitted)
 		So(dr.Error.Type, ShouldEqual, TypeDomainNotPermitted)
+		So(dr.MatchedDomain, ShouldEqual, "wiki.8191.at")
+		So(dr.Domain, ShouldEqual, "wiki.8191.at")
 	})
 
 	Convey("Passed email address is not blacklisted.", t, func() {
@@ -212,5 +269,237 @@ func TestHandleVerifyEmail(t *testing.T) {
 
 		So(dr.Status, ShouldBeTrue)
 		So(dr.Error, ShouldBeNil)
+		So(dr.Domain, ShouldEqual, "gmail.com")
+		So(dr.RegistrableDomain, ShouldEqual, "gmail.com")
+	})
+
+	Convey("Batch results are returned per address in order", t, func() {
+		req, err := http.NewRequest(
+			"POST",
+			fmt.Sprintf("http://localhost:%d/v1/verify/batch", httpPort),
+			bytes.NewBuffer([]byte(`{"emails": ["nevio.vesic@gmail.com", "brr", "buuf@wiki.8191.at"]}`)),
+		)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("disposable", "disposable123")
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+		So(resp.Header["Content-Type"], ShouldResemble, []string{"application/json"})
+
+		var br disposable.DisposableBatchResponse
+		jrerr := DecodeJSONBody(&br, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(br.Status, ShouldBeTrue)
+		So(br.Error, ShouldBeNil)
+		So(len(br.Results), ShouldEqual, 3)
+		So(br.Results[0].Status, ShouldBeTrue)
+		So(br.Results[1].Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(br.Results[2].Error.Type, ShouldEqual, TypeDomainNotPermitted)
+	})
+
+	Convey("Domain lookup reports listed parent entry", t, func() {
+		req, err := http.NewRequest(
+			"GET",
+			fmt.Sprintf("http://localhost:%d/v1/domains/mx.wiki.8191.at", httpPort),
+			nil,
+		)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("disposable", "disposable123")
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+		So(resp.Header["Content-Type"], ShouldResemble, []string{"application/json"})
+
+		var dr disposable.DisposableDomainResponse
+		jrerr := DecodeJSONBody(&dr, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(dr.Status, ShouldBeTrue)
+		So(dr.Listed, ShouldBeTrue)
+		So(dr.Domain, ShouldEqual, "mx.wiki.8191.at")
+		So(dr.MatchedDomain, ShouldEqual, "wiki.8191.at")
+		So(len(dr.Sources), ShouldEqual, 1)
+	})
+
+	Convey("Domain lookup requires valid domain", t, func() {
+		req, err := http.NewRequest(
+			"GET",
+			fmt.Sprintf("http://localhost:%d/v1/domains/not_a-domain!", httpPort),
+			nil,
+		)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("disposable", "disposable123")
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 400)
+
+		var dr disposable.DisposableDomainResponse
+		jrerr := DecodeJSONBody(&dr, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(dr.Status, ShouldBeFalse)
+		So(dr.Error.Type, ShouldEqual, TypeInvalidDomain)
+	})
+
+	Convey("Canonical mailbox is returned for email address", t, func() {
+		req, err := http.NewRequest(
+			"POST",
+			fmt.Sprintf("http://localhost:%d/v1/normalize", httpPort),
+			bytes.NewBuffer([]byte(`{"email": "John.Doe+news@GoogleMail.com"}`)),
+		)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("disposable", "disposable123")
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+		So(resp.Header["Content-Type"], ShouldResemble, []string{"application/json"})
+
+		var nr disposable.DisposableNormalizeResponse
+		jrerr := DecodeJSONBody(&nr, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(nr.Status, ShouldBeTrue)
+		So(nr.Canonical, ShouldEqual, "johndoe@gmail.com")
+		So(nr.Provider, ShouldEqual, "gmail")
+	})
+
+	Convey("Normalization requires valid email", t, func() {
+		req, err := http.NewRequest(
+			"POST",
+			fmt.Sprintf("http://localhost:%d/v1/normalize", httpPort),
+			bytes.NewBuffer([]byte(`{"email": "brr"}`)),
+		)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("disposable", "disposable123")
+
+		client := &http.Client{}
+		resp, err := client.Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 400)
+
+		var nr disposable.DisposableNormalizeResponse
+		jrerr := DecodeJSONBody(&nr, resp.Body)
+		So(jrerr, ShouldBeNil)
+
+		So(nr.Status, ShouldBeFalse)
+		So(nr.Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(nr.Error.Info["rule"], ShouldEqual, RuleMissingAt)
+	})
+
+	Convey("Snapshots are not listed with verification credentials", t, func() {
+		req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:%d/v1/admin/snapshots", httpPort), nil)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("disposable", "disposable123")
+
+		resp, err := (&http.Client{}).Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 401)
+	})
+
+	Convey("Snapshots of loaded lists are listed for admins", t, func() {
+		req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost:%d/v1/admin/snapshots", httpPort), nil)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("admin", "admin123")
+
+		resp, err := (&http.Client{}).Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 200)
+
+		var sl SnapshotList
+		So(DecodeJSONBody(&sl, resp.Body), ShouldBeNil)
+		So(len(sl.Snapshots), ShouldBeGreaterThan, 0)
+		So(sl.Snapshots[0].Serving, ShouldBeTrue)
+		So(sl.Snapshots[0].Version, ShouldEqual, sl.Serving)
+		So(sl.Pinned, ShouldBeEmpty)
+	})
+
+	Convey("Rolling back to unknown snapshot is not found", t, func() {
+		req, err := http.NewRequest("POST", fmt.Sprintf("http://localhost:%d/v1/admin/snapshots/0000000000000000/rollback", httpPort), nil)
+		So(err, ShouldBeNil)
+
+		req.SetBasicAuth("admin", "admin123")
+
+		resp, err := (&http.Client{}).Do(req)
+		So(err, ShouldBeNil)
+		defer resp.Body.Close()
+
+		So(resp.StatusCode, ShouldEqual, 404)
+
+		var dr disposable.DisposableResponse
+		So(DecodeJSONBody(&dr, resp.Body), ShouldBeNil)
+		So(dr.Error.Type, ShouldEqual, TypeSnapshotNotFound)
+	})
+}
+
+func TestAdminRoutes(t *testing.T) {
+	defer os.Setenv("ADMIN_USERNAME", os.Getenv("ADMIN_USERNAME"))
+	defer os.Setenv("ADMIN_PASSWORD", os.Getenv("ADMIN_PASSWORD"))
+
+	service := &Service{DisposableEmails: newSourcedEmails("mailinator.com")}
+
+	Convey("Admin routes are not registered without admin credentials", t, func() {
+		os.Setenv("ADMIN_USERNAME", "admin")
+		os.Setenv("ADMIN_PASSWORD", "")
+
+		httpmux := mux.NewRouter()
+		So(service.RegisterAdminRoutes(httpmux), ShouldBeFalse)
+
+		req := httptest.NewRequest("GET", "/v1/admin/snapshots", nil)
+		req.SetBasicAuth("admin", "")
+
+		w := httptest.NewRecorder()
+		httpmux.ServeHTTP(w, req)
+		So(w.Code, ShouldEqual, 404)
+	})
+
+	Convey("Admin routes require admin credentials", t, func() {
+		os.Setenv("ADMIN_USERNAME", "admin")
+		os.Setenv("ADMIN_PASSWORD", "admin123")
+
+		httpmux := mux.NewRouter()
+		So(service.RegisterAdminRoutes(httpmux), ShouldBeTrue)
+
+		for credentials, code := range map[[2]string]int{
+			{"admin", "admin123"}:           200,
+			{"admin", "wrong"}:              401,
+			{"disposable", "disposable123"}: 401,
+		} {
+			req := httptest.NewRequest("GET", "/v1/admin/snapshots", nil)
+			req.SetBasicAuth(credentials[0], credentials[1])
+
+			w := httptest.NewRecorder()
+			httpmux.ServeHTTP(w, req)
+			So(w.Code, ShouldEqual, code)
+		}
 	})
 }
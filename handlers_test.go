@@ -7,8 +7,10 @@ package main
 import (
 	"crypto/x509"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,8 +20,576 @@ import (
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+func TestVerifyBeforeLoad(t *testing.T) {
+	Convey("Verification is refused until disposable emails are loaded", t, func() {
+		service := &Service{DisposableEmails: &DisposableEmails{}}
+
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{
+			Email: "nevio.vesic@gmail.com",
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error, ShouldHaveSameTypeAs, &disposable.Error{})
+		So(resp.Error.Type, ShouldEqual, TypeServiceNotReady)
+		So(resp.Error.Message, ShouldEqual, ErrorServiceNotReady)
+	})
+}
+
+func TestVerifyBatch(t *testing.T) {
+	emails := &DisposableEmails{}
+	emails.Build([]string{"mailinator.com"})
+	service := &Service{DisposableEmails: emails, MaxBatchSize: 3}
+
+	Convey("Each address gets its own result in the same order", t, func() {
+		resp, err := service.VerifyBatch(context.Background(), &disposable.DisposableBatchRequest{
+			Emails: []string{"nevio.vesic@gmail.com", "buuf", "john@mailinator.com"},
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Error, ShouldBeNil)
+		So(len(resp.Results), ShouldEqual, 3)
+
+		So(resp.Results[0].Status, ShouldBeTrue)
+		So(resp.Results[1].Status, ShouldBeFalse)
+		So(resp.Results[1].Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(resp.Results[2].Status, ShouldBeFalse)
+		So(resp.Results[2].Error.Type, ShouldEqual, TypeDomainNotPermitted)
+		So(resp.Results[2].MatchedDomain, ShouldEqual, "mailinator.com")
+	})
+
+	Convey("Batch larger than allowed is rejected as a whole", t, func() {
+		resp, err := service.VerifyBatch(context.Background(), &disposable.DisposableBatchRequest{
+			Emails: []string{"a@gmail.com", "b@gmail.com", "c@gmail.com", "d@gmail.com"},
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeBatchTooLarge)
+		So(resp.Error.Info["max_batch_size"], ShouldEqual, "3")
+		So(resp.Results, ShouldBeEmpty)
+	})
+
+	Convey("Batch size limit of zero or less falls back to default", t, func() {
+		for _, size := range []int{0, -1} {
+			unlimited := &Service{DisposableEmails: emails, MaxBatchSize: size}
+
+			resp, err := unlimited.VerifyBatch(context.Background(), &disposable.DisposableBatchRequest{
+				Emails: []string{"a@gmail.com", "b@gmail.com", "c@gmail.com", "d@gmail.com"},
+			})
+
+			So(err, ShouldBeNil)
+			So(resp.Status, ShouldBeTrue)
+			So(len(resp.Results), ShouldEqual, 4)
+		}
+
+		unlimited := &Service{DisposableEmails: emails}
+		resp, err := unlimited.VerifyBatch(context.Background(), &disposable.DisposableBatchRequest{
+			Emails: make([]string, DefaultMaxBatchSize+1),
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Info["max_batch_size"], ShouldEqual, fmt.Sprint(DefaultMaxBatchSize))
+	})
+
+	Convey("Whole batch is verified against the same list while it reloads", t, func() {
+		reloading := &DisposableEmails{}
+		reloading.Build([]string{"mailinator.com"})
+		service := &Service{DisposableEmails: reloading}
+
+		batch := make([]string, 500)
+		for i := range batch {
+			batch[i] = fmt.Sprintf("john-%d@gmail.com", i)
+		}
+
+		done := make(chan bool)
+		go func() {
+			defer close(done)
+
+			for i := 0; i < 50; i++ {
+				reloading.Build([]string{fmt.Sprintf("burner-%d.example.com", i)})
+			}
+		}()
+
+		for i := 0; i < 10; i++ {
+			resp, err := service.VerifyBatch(context.Background(), &disposable.DisposableBatchRequest{Emails: batch})
+			So(err, ShouldBeNil)
+
+			versions := map[string]bool{}
+			for _, result := range resp.Results {
+				versions[result.ListVersion] = true
+			}
+			So(len(versions), ShouldEqual, 1)
+		}
+
+		<-done
+	})
+
+	Convey("Cancelled batch stops verification", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		resp, err := service.VerifyBatch(ctx, &disposable.DisposableBatchRequest{
+			Emails: []string{"a@gmail.com"},
+		})
+
+		So(resp, ShouldBeNil)
+		So(err, ShouldEqual, context.Canceled)
+	})
+}
+
+// newSourcedEmails - Will build disposable emails out of provided domains as
+// if they were read from burner source file.
+func newSourcedEmails(domains ...string) *DisposableEmails {
+	index := NewDomainIndex(nil, nil)
+	index.AddList(domains, index.AddFile(ListFile{Source: "burner", Path: "services/burner/emails.txt"}))
+
+	emails := &DisposableEmails{}
+	emails.Swap(index)
+	return emails
+}
+
+func TestVerifyExplain(t *testing.T) {
+	emails := newSourcedEmails("guerrillamail.com", "mailinator.com")
+	service := &Service{DisposableEmails: emails}
+
+	Convey("Explanation is returned only when asked for", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{
+			Email: "john@mailinator.com",
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Explanation, ShouldBeNil)
+	})
+
+	Convey("Rejection explains list, source and line that matched", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{
+			Email:   "john@mx.mailinator.com",
+			Explain: true,
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Explanation, ShouldNotBeNil)
+		So(resp.Explanation.ListVersion, ShouldEqual, emails.Index().Version())
+		So(len(resp.Explanation.Checks), ShouldEqual, 2)
+
+		So(resp.Explanation.Checks[0].Name, ShouldEqual, CheckSyntax)
+		So(resp.Explanation.Checks[0].Passed, ShouldBeTrue)
+
+		check := resp.Explanation.Checks[1]
+		So(check.Name, ShouldEqual, CheckBlocklist)
+		So(check.Passed, ShouldBeFalse)
+		So(check.List, ShouldEqual, ListDisposable)
+		So(check.MatchedDomain, ShouldEqual, "mailinator.com")
+		So(check.Source, ShouldEqual, "burner")
+		So(check.File, ShouldEqual, "services/burner/emails.txt")
+		So(check.Line, ShouldEqual, 2)
+		So(resp.Sources, ShouldResemble, []string{"burner"})
+	})
+
+	Convey("Syntax rejection explains which rule failed", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{
+			Email:   "john.mailinator.com",
+			Explain: true,
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(len(resp.Explanation.Checks), ShouldEqual, 1)
+		So(resp.Explanation.Checks[0].Name, ShouldEqual, CheckSyntax)
+		So(resp.Explanation.Checks[0].Passed, ShouldBeFalse)
+		So(resp.Explanation.Checks[0].Rule, ShouldEqual, RuleMissingAt)
+	})
+
+	Convey("Syntax rules are reported one by one", t, func() {
+		So(EmailSyntaxRule("john@gmail.com"), ShouldBeEmpty)
+		So(EmailSyntaxRule("@gmail.com"), ShouldEqual, RuleEmptyLocalPart)
+		So(EmailSyntaxRule(strings.Repeat("a", 65)+"@gmail.com"), ShouldEqual, RuleLocalPartTooLong)
+		So(EmailSyntaxRule("john@"+strings.Repeat("a", 250)+".com"), ShouldEqual, RuleAddressTooLong)
+		So(EmailSyntaxRule("john@"), ShouldEqual, RuleEmptyDomain)
+		So(EmailSyntaxRule("john@gmail..com"), ShouldEqual, RuleInvalidDomain)
+		So(EmailSyntaxRule("jo hn@gmail.com"), ShouldEqual, RuleInvalidLocalPart)
+	})
+}
+
+func TestVerifyAllowlist(t *testing.T) {
+	emails := newSourcedEmails("mailinator.com", "guerrillamail.com")
+
+	allow := NewDomainIndex(nil, nil)
+	allow.AddList([]string{"relay.mailinator.com", "=guerrillamail.com"}, allow.AddFile(ListFile{Source: "partners", Path: "allow.txt"}))
+	emails.Index().SetAllowlist(allow)
+
+	service := &Service{DisposableEmails: emails}
+
+	Convey("Allowlist suffix entry overrides the list", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{
+			Email:   "john@mx.relay.mailinator.com",
+			Explain: true,
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Error, ShouldBeNil)
+		So(resp.Override, ShouldBeTrue)
+		So(resp.AllowedDomain, ShouldEqual, "relay.mailinator.com")
+		So(resp.MatchedDomain, ShouldEqual, "mailinator.com")
+
+		So(len(resp.Explanation.Checks), ShouldEqual, 3)
+		So(resp.Explanation.Checks[1].Name, ShouldEqual, CheckAllowlist)
+		So(resp.Explanation.Checks[1].Source, ShouldEqual, "partners")
+		So(resp.Explanation.Checks[1].Line, ShouldEqual, 1)
+		So(resp.Explanation.Checks[2].Rule, ShouldEqual, RuleAllowlistOverride)
+	})
+
+	Convey("Exact allowlist entry does not cover subdomains", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@mx.guerrillamail.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Override, ShouldBeFalse)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@guerrillamail.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Override, ShouldBeTrue)
+	})
+
+	Convey("Allowlist is consulted by IsOK and domain lookups", t, func() {
+		So(emails.IsOK("john@relay.mailinator.com"), ShouldBeTrue)
+		So(emails.IsOK("john@mailinator.com"), ShouldBeFalse)
+
+		resp, err := service.CheckDomain(context.Background(), &disposable.DisposableDomainRequest{Domain: "relay.mailinator.com"})
+		So(err, ShouldBeNil)
+		So(resp.Listed, ShouldBeFalse)
+		So(resp.Override, ShouldBeTrue)
+		So(resp.AllowedDomain, ShouldEqual, "relay.mailinator.com")
+	})
+}
+
+func TestCheckDomain(t *testing.T) {
+	service := &Service{DisposableEmails: newSourcedEmails("mailinator.com", `/^mail-[0-9]+\.tld$/`)}
+
+	Convey("Listed domain reports matched entry and its source", t, func() {
+		resp, err := service.CheckDomain(context.Background(), &disposable.DisposableDomainRequest{
+			Domain: "MX1.Mailinator.com",
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Error, ShouldBeNil)
+		So(resp.Listed, ShouldBeTrue)
+		So(resp.Domain, ShouldEqual, "mx1.mailinator.com")
+		So(resp.MatchedDomain, ShouldEqual, "mailinator.com")
+		So(resp.MatchedRule, ShouldEqual, RuleSuffix)
+		So(resp.Sources, ShouldResemble, []string{"burner"})
+	})
+
+	Convey("Domain matched by pattern reports the pattern that fired", t, func() {
+		resp, err := service.CheckDomain(context.Background(), &disposable.DisposableDomainRequest{
+			Domain: "mail-42.tld",
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Listed, ShouldBeTrue)
+		So(resp.MatchedDomain, ShouldEqual, `/^mail-[0-9]+\.tld$/`)
+		So(resp.MatchedRule, ShouldEqual, RuleRegex)
+		So(resp.Sources, ShouldResemble, []string{"burner"})
+	})
+
+	Convey("Domain that is not listed is not an error", t, func() {
+		resp, err := service.CheckDomain(context.Background(), &disposable.DisposableDomainRequest{
+			Domain: "gmail.com",
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Listed, ShouldBeFalse)
+		So(resp.MatchedDomain, ShouldBeEmpty)
+		So(resp.Sources, ShouldBeEmpty)
+	})
+
+	Convey("Email address is not a valid domain", t, func() {
+		resp, err := service.CheckDomain(context.Background(), &disposable.DisposableDomainRequest{
+			Domain: "john@mailinator.com",
+		})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeInvalidDomain)
+	})
+}
+
+// blockingVerifyStream - Server side of verify stream that never receives
+// anything until its context is done.
+type blockingVerifyStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (bs *blockingVerifyStream) Context() context.Context {
+	return bs.ctx
+}
+
+func (bs *blockingVerifyStream) Send(*disposable.DisposableStreamResponse) error {
+	return nil
+}
+
+func (bs *blockingVerifyStream) Recv() (*disposable.DisposableStreamRequest, error) {
+	<-bs.ctx.Done()
+	return nil, bs.ctx.Err()
+}
+
+func TestVerifyHistorical(t *testing.T) {
+	emails := newSourcedEmails("mailinator.com")
+	service := &Service{DisposableEmails: emails}
+
+	before := emails.Index().Version()
+	time.Sleep(10 * time.Millisecond)
+	between := time.Now()
+	time.Sleep(10 * time.Millisecond)
+
+	emails.Build([]string{"mailinator.com", "gmail.com"})
+	after := emails.Index().Version()
+
+	Convey("Address is verified against currently serving list by default", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com"})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.ListVersion, ShouldEqual, after)
+	})
+
+	Convey("Address is verified against list of requested version", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", ListVersion: before})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.ListVersion, ShouldEqual, before)
+	})
+
+	Convey("Address is verified against list serving at requested time", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", At: between.Format(time.RFC3339Nano)})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.ListVersion, ShouldEqual, before)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", At: time.Now().Format(time.RFC3339Nano)})
+		So(err, ShouldBeNil)
+		So(resp.ListVersion, ShouldEqual, after)
+	})
+
+	Convey("Lists not kept in history can not be verified against", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", ListVersion: "0000000000000000"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeSnapshotNotFound)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", At: "2001-01-01T00:00:00Z"})
+		So(err, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeSnapshotNotFound)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", At: "yesterday"})
+		So(err, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeInvalidTimestamp)
+	})
+}
+
+func TestVerifyInternational(t *testing.T) {
+	service := &Service{DisposableEmails: newSourcedEmails("müllmail.de")}
+
+	Convey("Internationalized domains are matched on their A-label", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@MÜLLMAIL.de"})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeDomainNotPermitted)
+		So(resp.Domain, ShouldEqual, "xn--mllmail-n2a.de")
+		So(resp.MatchedDomain, ShouldEqual, "xn--mllmail-n2a.de")
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@müller.de"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+	})
+
+	Convey("UTF-8 local parts are accepted only with SMTPUTF8 enabled", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "jöhn@müller.de"})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(resp.Error.Info["rule"], ShouldEqual, RuleInvalidLocalPart)
+
+		service.SMTPUTF8 = true
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "jöhn@müller.de"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "jöhn@müllmail.de"})
+		So(err, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeDomainNotPermitted)
+	})
+}
+
+func TestVerifyConfusable(t *testing.T) {
+	emails := newSourcedEmails("mailinator.com")
+	confusables := NewEmbeddedConfusables()
+	confusables.Protect([]string{"gmail.com"})
+	service := &Service{DisposableEmails: emails, Confusables: confusables}
+
+	Convey("Lookalikes of protected domains are rejected", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@mx.gmaіl.com", Explain: true})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeDomainConfusable)
+		So(resp.Error.Info["reason"], ShouldEqual, ConfusableSkeleton)
+		So(resp.Error.Info["protected"], ShouldEqual, "gmail.com")
+		So(resp.RegistrableDomain, ShouldEqual, "xn--gmal-n9d.com")
+
+		check := resp.Explanation.Checks[len(resp.Explanation.Checks)-1]
+		So(check.Name, ShouldEqual, CheckConfusable)
+		So(check.Passed, ShouldBeFalse)
+		So(check.MatchedDomain, ShouldEqual, "gmail.com")
+	})
+
+	Convey("Domains mixing scripts are rejected", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@exаmple.com"})
+
+		So(err, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeDomainConfusable)
+		So(resp.Error.Info["reason"], ShouldEqual, ConfusableMixedScript)
+		So(resp.Error.Info["scripts"], ShouldEqual, "Cyrillic,Latin")
+	})
+
+	Convey("Protected domains themselves and allowlisted lookalikes pass", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com", Explain: true})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Explanation.Checks[1].Name, ShouldEqual, CheckConfusable)
+		So(resp.Explanation.Checks[1].Passed, ShouldBeTrue)
+
+		index := NewDomainIndex([]string{"mailinator.com"}, nil)
+		index.SetAllowlist(NewDomainIndex([]string{"gmaіl.com"}, nil))
+		emails.Swap(index)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmaіl.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+	})
+}
+
+func TestVerifySuggestion(t *testing.T) {
+	emails := newSourcedEmails("gmial.com")
+	service := &Service{DisposableEmails: emails, Suggester: NewSuggester([]string{"gmail.com", "yahoo.com"}, []string{"com"})}
+
+	Convey("Suggestion does not change the decision by default", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@yahoo.con", Explain: true})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.DidYouMean, ShouldEqual, "john@yahoo.com")
+		So(resp.Explanation.Checks[1].Name, ShouldEqual, CheckTypo)
+		So(resp.Explanation.Checks[1].Passed, ShouldBeTrue)
+		So(resp.Explanation.Checks[1].MatchedDomain, ShouldEqual, "yahoo.com")
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmial.com"})
+		So(err, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeDomainNotPermitted)
+		So(resp.DidYouMean, ShouldBeEmpty)
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmail.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.DidYouMean, ShouldBeEmpty)
+	})
+
+	Convey("Mistyped addresses are rejected in strict mode", t, func() {
+		service.StrictSuggestions = true
+		defer func() { service.StrictSuggestions = false }()
+
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@yahoo.con"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeDomainTypo)
+		So(resp.DidYouMean, ShouldEqual, "john@yahoo.com")
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@gmal.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.DidYouMean, ShouldEqual, "john@gmail.com")
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "john@example.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	service := &Service{DisposableEmails: newSourcedEmails("mailinator.com"), ProviderRules: NewEmbeddedProviderRules()}
+
+	Convey("Canonical mailbox is returned along with rules that derived it", t, func() {
+		resp, err := service.Normalize(context.Background(), &disposable.DisposableNormalizeRequest{Email: "J.Doe+Shop@googlemail.com"})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Email, ShouldEqual, "J.Doe+Shop@googlemail.com")
+		So(resp.Canonical, ShouldEqual, "jdoe@gmail.com")
+		So(resp.Provider, ShouldEqual, "gmail")
+		So(resp.Rules, ShouldResemble, []string{NormalizeLowercase, NormalizeSubaddress, NormalizeDots, NormalizeAlias})
+	})
+
+	Convey("Invalid addresses are not normalized", t, func() {
+		resp, err := service.Normalize(context.Background(), &disposable.DisposableNormalizeRequest{Email: "john..doe@gmail.com"})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeFalse)
+		So(resp.Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(resp.Canonical, ShouldBeEmpty)
+	})
+
+	Convey("Verified addresses carry their canonical form", t, func() {
+		resp, err := service.Verify(context.Background(), &disposable.DisposableRequest{Email: "John+x@Mailinator.com"})
+
+		So(err, ShouldBeNil)
+		So(resp.Error.Type, ShouldEqual, TypeDomainNotPermitted)
+		So(resp.Canonical, ShouldEqual, "John+x@mailinator.com")
+
+		resp, err = service.Verify(context.Background(), &disposable.DisposableRequest{Email: "j.o.h.n@gmail.com"})
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldBeTrue)
+		So(resp.Canonical, ShouldEqual, "john@gmail.com")
+	})
+}
+
+func TestVerifyStreamCancel(t *testing.T) {
+	Convey("Cancelled stream stops verification right away", t, func() {
+		emails := &DisposableEmails{}
+		emails.Build([]string{"mailinator.com"})
+		service := &Service{DisposableEmails: emails, StreamConcurrency: 2}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+
+		go func() { done <- service.VerifyStream(&blockingVerifyStream{ctx: ctx}) }()
+		cancel()
+
+		select {
+		case err := <-done:
+			So(err, ShouldNotBeNil)
+		case <-time.After(2 * time.Second):
+			So("stream still running", ShouldBeNil)
+		}
+	})
+}
+
 func TestVerifyEmail(t *testing.T) {
 	var service *Service
 	var err error
@@ -46,6 +616,68 @@ func TestVerifyEmail(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 
+	Convey("Health service reports serving once disposable emails are loaded", t, func() {
+		opts := []grpc.DialOption{}
+		creds := credentials.NewClientTLSFromCert(caCertPool, caHost)
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+		opts = append(opts, grpc.WithBlock())
+		conn, err := grpc.Dial(fmt.Sprintf(":%d", grpcPort), opts...)
+		So(err, ShouldBeNil)
+		defer conn.Close()
+
+		timeout, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		client := healthpb.NewHealthClient(conn)
+		resp, err := client.Check(timeout, &healthpb.HealthCheckRequest{Service: DisposableServiceName})
+
+		So(err, ShouldBeNil)
+		So(resp.Status, ShouldEqual, healthpb.HealthCheckResponse_SERVING)
+	})
+
+	Convey("Streamed addresses are verified and correlated by id", t, func() {
+		opts := []grpc.DialOption{}
+		creds := credentials.NewClientTLSFromCert(caCertPool, caHost)
+		opts = append(opts, grpc.WithTransportCredentials(creds))
+		opts = append(opts, grpc.WithBlock())
+		conn, err := grpc.Dial(fmt.Sprintf(":%d", grpcPort), opts...)
+		So(err, ShouldBeNil)
+		defer conn.Close()
+
+		timeout, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+
+		client := disposable.NewDisposableServiceClient(conn)
+		stream, err := client.VerifyStream(timeout)
+		So(err, ShouldBeNil)
+
+		emails := map[string]string{
+			"1": "nevio.vesic@gmail.com",
+			"2": "buuf",
+			"3": "buuf@wiki.8191.at",
+		}
+
+		for id, email := range emails {
+			So(stream.Send(&disposable.DisposableStreamRequest{Id: id, Email: email}), ShouldBeNil)
+		}
+		So(stream.CloseSend(), ShouldBeNil)
+
+		results := map[string]*disposable.DisposableResponse{}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			So(err, ShouldBeNil)
+			results[resp.Id] = resp.Result
+		}
+
+		So(len(results), ShouldEqual, 3)
+		So(results["1"].Status, ShouldBeTrue)
+		So(results["2"].Error.Type, ShouldEqual, TypeInvalidEmailAddress)
+		So(results["3"].Error.Type, ShouldEqual, TypeDomainNotPermitted)
+	})
+
 	Convey("Email address is required", t, func() {
 		opts := []grpc.DialOption{}
 		creds := credentials.NewClientTLSFromCert(caCertPool, caHost)
@@ -0,0 +1,132 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"sync/atomic"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthReport - Snapshot of service health served by /healthz and /readyz and
+// mirrored into GRPC health service.
+type HealthReport struct {
+	// Live - Both GRPC and HTTP listeners are accepting connections
+	Live bool `json:"live"`
+
+	// Ready - Service is live and serving fresh disposable emails list loaded
+	// from configured sources
+	Ready bool `json:"ready"`
+
+	GRPCListening bool `json:"grpc_listening"`
+	HTTPListening bool `json:"http_listening"`
+
+	ListLoaded   bool       `json:"list_loaded"`
+	ListStale    bool       `json:"list_stale"`
+	ListDomains  int        `json:"list_domains"`
+	ListLoadedAt *time.Time `json:"list_loaded_at,omitempty"`
+
+	// ListLoadError - Why the latest (re)load failed, for example because list
+	// signature could not be verified. Previously loaded list keeps serving.
+	ListLoadError   string     `json:"list_load_error,omitempty"`
+	ListLoadErrorAt *time.Time `json:"list_load_error_at,omitempty"`
+
+	// ListVersion - Version of the serving list. ListPinned is set while
+	// serving a snapshot rolled back to instead of the latest loaded list.
+	ListVersion string `json:"list_version"`
+	ListPinned  string `json:"list_pinned,omitempty"`
+
+	// ListFallback - Embedded list is serving as none of the sources could be
+	// loaded yet. EmbeddedListDate and EmbeddedListCommit tell how old it
+	// is. Service keeps being ready while it does but is degraded.
+	ListFallback       bool   `json:"list_fallback"`
+	EmbeddedListDate   string `json:"embedded_list_date"`
+	EmbeddedListCommit string `json:"embedded_list_commit"`
+
+	// RemoteSources - Last fetch success and error of every remote source.
+	// Failing remote sources don't affect readiness as their last good copy
+	// keeps serving.
+	RemoteSources []RemoteStatus `json:"remote_sources,omitempty"`
+}
+
+// HealthReport - Will collect current health of the service
+func (s *Service) HealthReport() *HealthReport {
+	r := &HealthReport{
+		GRPCListening: atomic.LoadInt32(&s.grpcListening) == 1,
+		HTTPListening: atomic.LoadInt32(&s.httpListening) == 1,
+		ListLoaded:    s.DisposableEmails.Loaded(),
+		ListStale:     s.DisposableEmails.Stale(),
+		ListDomains:   s.DisposableEmails.Len(),
+		RemoteSources: s.DisposableEmails.RemoteStatuses(),
+		ListVersion:   s.DisposableEmails.Index().Version(),
+		ListPinned:    s.DisposableEmails.Pinned(),
+
+		ListFallback:       s.DisposableEmails.ServingFallback(),
+		EmbeddedListDate:   EmbeddedListDate(),
+		EmbeddedListCommit: EmbeddedListCommit(),
+	}
+
+	if r.ListLoaded {
+		loadedAt := s.DisposableEmails.LoadedAt()
+		r.ListLoadedAt = &loadedAt
+	}
+
+	if loadErr, at := s.DisposableEmails.LoadError(); loadErr != "" {
+		r.ListLoadError = loadErr
+		r.ListLoadErrorAt = &at
+	}
+
+	r.Live = r.GRPCListening && r.HTTPListening
+	r.Ready = r.Live && r.ListLoaded && !r.ListStale
+	return r
+}
+
+// UpdateHealth - Will mirror current health report into GRPC health service
+func (s *Service) UpdateHealth() *HealthReport {
+	r := s.HealthReport()
+
+	if r.Ready {
+		s.SetServingStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		s.SetServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return r
+}
+
+// MonitorHealth - Will keep GRPC health service up to date as list ages and
+// listeners come and go. Blocks until service context is done.
+func (s *Service) MonitorHealth(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	ready := false
+
+	for {
+		select {
+		case <-ticker.C:
+			if r := s.UpdateHealth(); r.Ready != ready {
+				log.Infof("[monitor_health] Service readiness changed to (ready: %t) - (report: %+v)", r.Ready, r)
+				ready = r.Ready
+			}
+		case <-s.Ctx.Done():
+			return
+		}
+	}
+}
+
+// setListening - Will flag listener as (not) accepting connections and
+// refresh health right away.
+func (s *Service) setListening(flag *int32, listening bool) {
+	var v int32
+	if listening {
+		v = 1
+	}
+
+	atomic.StoreInt32(flag, v)
+	s.UpdateHealth()
+}
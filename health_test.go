@@ -0,0 +1,81 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc/health"
+)
+
+func TestHealthReport(t *testing.T) {
+	Convey("Service is neither live nor ready before listeners are up", t, func() {
+		service := &Service{Health: health.NewServer(), DisposableEmails: &DisposableEmails{}}
+
+		r := service.HealthReport()
+		So(r.Live, ShouldBeFalse)
+		So(r.Ready, ShouldBeFalse)
+		So(r.ListLoaded, ShouldBeFalse)
+		So(r.ListLoadedAt, ShouldBeNil)
+		So(r.ListFallback, ShouldBeFalse)
+		So(r.EmbeddedListDate, ShouldEqual, EmbeddedListDate())
+		So(r.EmbeddedListCommit, ShouldEqual, EmbeddedListCommit())
+	})
+
+	Convey("Service is live but not ready until list is loaded", t, func() {
+		service := &Service{Health: health.NewServer(), DisposableEmails: &DisposableEmails{}}
+		service.setListening(&service.grpcListening, true)
+		service.setListening(&service.httpListening, true)
+
+		r := service.HealthReport()
+		So(r.Live, ShouldBeTrue)
+		So(r.Ready, ShouldBeFalse)
+
+		service.DisposableEmails.Build([]string{"mailinator.com"})
+
+		r = service.HealthReport()
+		So(r.Ready, ShouldBeTrue)
+		So(r.ListDomains, ShouldEqual, 1)
+		So(r.ListLoadedAt, ShouldNotBeNil)
+
+		service.setListening(&service.grpcListening, false)
+		r = service.HealthReport()
+		So(r.Live, ShouldBeFalse)
+		So(r.Ready, ShouldBeFalse)
+	})
+
+	Convey("Embedded fallback keeps service ready but reports it", t, func() {
+		service := &Service{Health: health.NewServer(), DisposableEmails: &DisposableEmails{
+			Sources:          []ListSource{{Name: "burner", Path: "/nonexistent/emails.txt"}},
+			EmbeddedFallback: true,
+		}}
+		service.setListening(&service.grpcListening, true)
+		service.setListening(&service.httpListening, true)
+		So(service.DisposableEmails.Load(), ShouldBeNil)
+
+		r := service.HealthReport()
+		So(r.Live, ShouldBeTrue)
+		So(r.ListLoaded, ShouldBeTrue)
+		So(r.ListFallback, ShouldBeTrue)
+		So(r.EmbeddedListDate, ShouldEqual, EmbeddedListDate())
+		So(r.Ready, ShouldBeTrue)
+	})
+
+	Convey("Stale list makes service not ready", t, func() {
+		service := &Service{Health: health.NewServer(), DisposableEmails: &DisposableEmails{MaxAge: time.Millisecond}}
+		service.setListening(&service.grpcListening, true)
+		service.setListening(&service.httpListening, true)
+		service.DisposableEmails.Build([]string{"mailinator.com"})
+
+		time.Sleep(5 * time.Millisecond)
+
+		r := service.HealthReport()
+		So(r.Live, ShouldBeTrue)
+		So(r.ListStale, ShouldBeTrue)
+		So(r.Ready, ShouldBeFalse)
+	})
+}
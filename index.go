@@ -0,0 +1,414 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/idna"
+)
+
+// ExactEntryPrefix - List entries starting with this prefix opt out of
+// subdomain matching and block only the domain itself.
+const ExactEntryPrefix = "="
+
+// NoFile - File id of entries that were not read from any list file
+const NoFile = -1
+
+// ListFile - Single file list entries were read from along with name of the
+// source it belongs to.
+type ListFile struct {
+	Source string `json:"source"`
+	Path   string `json:"path"`
+	Format string `json:"format,omitempty"`
+}
+
+// indexEntry - What is known about a single listed domain
+type indexEntry struct {
+	// exact entries block only the domain itself, never its subdomains
+	exact bool
+
+	// file and line entry was first read from. Line is 1 based.
+	file int
+	line int
+}
+
+// DomainIndex - Normalized set of listed domains. Lookup cost is a single map
+// access per label of the looked up domain no matter how many domains are
+// indexed.
+type DomainIndex struct {
+	domains  map[string]indexEntry
+	list     []string
+	files    []ListFile
+	suffixes *PublicSuffixList
+	loadedAt time.Time
+
+	// fetchedAt is the oldest last successful fetch of remote sources index
+	// was built out of, zero when none of them is remote
+	fetchedAt time.Time
+
+	// also maps domains listed by more than one file to the other files
+	also map[string][]int
+
+	// allow holds entries overriding this index, nil when there are none
+	allow *DomainIndex
+
+	// hash is fed every indexed entry and identifies list content
+	hash hash.Hash
+
+	// patterns holds compiled glob and RE2 entries in the order they were
+	// indexed
+	patterns []*PatternRule
+}
+
+// AddFile - Will register list file entries are about to be read from and
+// return its id to be passed to Add.
+func (di *DomainIndex) AddFile(file ListFile) int {
+	di.files = append(di.files, file)
+	return len(di.files) - 1
+}
+
+// AddList - Will index provided domains, one per line of provided file, and
+// return number of newly indexed ones.
+func (di *DomainIndex) AddList(domains []string, file int) int {
+	added := 0
+
+	for i, domain := range domains {
+		if di.Add(domain, file, i+1) {
+			added++
+		}
+	}
+
+	return added
+}
+
+// AddEntries - Will index entries parsed out of provided file and return
+// number of newly indexed ones.
+func (di *DomainIndex) AddEntries(entries []ListEntry, file int) int {
+	added := 0
+
+	for _, entry := range entries {
+		if di.Add(entry.Domain, file, entry.Line) {
+			added++
+		}
+	}
+
+	return added
+}
+
+// Add - Will normalize and index provided domain read from provided file and
+// line. Returns false for blank domains and for domains that are already
+// indexed in which case the first entry wins and the file is only recorded as
+// another provenance of the domain. Glob and RE2 entries are compiled and
+// indexed as pattern rules, the ones that fail to compile are skipped.
+func (di *DomainIndex) Add(domain string, file, line int) bool {
+	domain = strings.TrimSpace(domain)
+
+	if IsPattern(domain) {
+		return di.addPattern(domain, file, line)
+	}
+
+	exact := strings.HasPrefix(domain, ExactEntryPrefix)
+
+	if domain = NormalizeDomain(strings.TrimPrefix(domain, ExactEntryPrefix)); domain == "" {
+		return false
+	}
+
+	return di.insert(domain, indexEntry{exact: exact, file: file, line: line})
+}
+
+// addPattern - Will compile and index provided glob or RE2 entry
+func (di *DomainIndex) addPattern(pattern string, file, line int) bool {
+	rule, err := CompilePattern(pattern)
+	if err != nil {
+		metricListPatternErrors.Add(1)
+		log.Warnf("[index] Skipping (pattern: %s) on (line: %d) due to (err: %s)", pattern, line, err)
+		return false
+	}
+
+	if !di.insert(rule.Pattern, indexEntry{file: file, line: line}) {
+		return false
+	}
+
+	di.patterns = append(di.patterns, rule)
+	return true
+}
+
+// insert - Will index entry under provided key unless key is already indexed
+// in which case provided file is only recorded as another provenance.
+func (di *DomainIndex) insert(key string, entry indexEntry) bool {
+	if existing, ok := di.domains[key]; ok {
+		if entry.file != NoFile && entry.file != existing.file && !containsFile(di.also[key], entry.file) {
+			di.also[key] = append(di.also[key], entry.file)
+		}
+		return false
+	}
+
+	di.domains[key] = entry
+	di.list = append(di.list, key)
+
+	if entry.exact {
+		di.hash.Write([]byte(ExactEntryPrefix))
+	}
+	di.hash.Write([]byte(key + "\n"))
+	return true
+}
+
+// Contains - Will check if provided domain is indexed
+func (di *DomainIndex) Contains(domain string) bool {
+	_, ok := di.domains[entryKey(domain)]
+	return ok
+}
+
+// Line - Will return line provided listed domain was read from or zero if
+// domain is not listed.
+func (di *DomainIndex) Line(domain string) int {
+	return di.domains[entryKey(domain)].line
+}
+
+// Origin - Will return file provided listed domain was first read from. False
+// is returned for domains that are not listed or were not read from a file.
+func (di *DomainIndex) Origin(domain string) (ListFile, bool) {
+	entry, ok := di.domains[entryKey(domain)]
+	if !ok || entry.file == NoFile {
+		return ListFile{}, false
+	}
+
+	return di.files[entry.file], true
+}
+
+// Provenance - Will return names of all sources that list provided domain in
+// the order they were loaded.
+func (di *DomainIndex) Provenance(domain string) []string {
+	domain = entryKey(domain)
+
+	entry, ok := di.domains[domain]
+	if !ok || entry.file == NoFile {
+		return nil
+	}
+
+	sources := []string{di.files[entry.file].Source}
+
+	for _, file := range di.also[domain] {
+		if source := di.files[file].Source; !StringInSlice(source, sources) {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
+
+// Files - Will return list files index was built from
+func (di *DomainIndex) Files() []ListFile {
+	return di.files
+}
+
+// Version - Will return short content hash of indexed entries and attached
+// allowlist. Indexes built out of the same entries in the same order share
+// the version.
+func (di *DomainIndex) Version() string {
+	sum := di.hash.Sum(nil)
+
+	if di.allow != nil && di.allow.Len() > 0 {
+		combined := sha256.Sum256(di.allow.hash.Sum(sum))
+		sum = combined[:]
+	}
+
+	return hex.EncodeToString(sum)[:16]
+}
+
+// Match - Will look provided domain and its parent domains up to the
+// registrable one up in the index and return the entry that matched, closest
+// one first. Exact-only entries match just the domain itself, never its
+// subdomains. Pattern rules are tried only when no domain entry matched and
+// the first one matching domain or any of its parents wins.
+func (di *DomainIndex) Match(domain string) (string, bool) {
+	domain = NormalizeDomain(domain)
+	candidates := di.candidates(domain)
+
+	for _, suffix := range candidates {
+		if entry, ok := di.domains[suffix]; ok && (!entry.exact || suffix == domain) {
+			return suffix, true
+		}
+	}
+
+	for _, suffix := range candidates {
+		for _, rule := range di.patterns {
+			if rule.MatchString(suffix) {
+				return rule.Pattern, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// candidates - Will return provided domain followed by its parent domains up
+// to the registrable one. Parents above it are public suffixes such as co.uk
+// or github.io and must never block anything.
+func (di *DomainIndex) candidates(domain string) []string {
+	registrable := di.suffixes.RegistrableDomain(domain)
+	candidates := []string{domain}
+
+	for suffix := domain; registrable != "" && len(suffix) > len(registrable); {
+		suffix = suffix[strings.IndexByte(suffix, '.')+1:]
+		candidates = append(candidates, suffix)
+	}
+
+	return candidates
+}
+
+// Parent - Will return entry other than provided domain itself that matches
+// it, making domain redundant in the list. Exact-only entries match nothing
+// but themselves and parents above registrable domain are never considered.
+func (di *DomainIndex) Parent(domain string) (string, bool) {
+	domain = NormalizeDomain(domain)
+	registrable := di.suffixes.RegistrableDomain(domain)
+
+	for suffix := domain; registrable != "" && len(suffix) > len(registrable); {
+		suffix = suffix[strings.IndexByte(suffix, '.')+1:]
+
+		if entry, ok := di.domains[suffix]; ok && !entry.exact {
+			return suffix, true
+		}
+	}
+
+	return "", false
+}
+
+// Allow - Will look provided domain up in allowlist attached to the index and
+// return allowlist entry that matched. Same exact and suffix rules as in Match
+// apply.
+func (di *DomainIndex) Allow(domain string) (string, bool) {
+	if di.allow == nil {
+		return "", false
+	}
+
+	return di.allow.Match(domain)
+}
+
+// Allowlist - Will return allowlist attached to the index or nil if there is
+// none.
+func (di *DomainIndex) Allowlist() *DomainIndex {
+	return di.allow
+}
+
+// SetAllowlist - Will attach provided allowlist to the index. Allowlist
+// entries win over entries of the index itself. Allowlist is expected to be
+// built against the same public suffix list and is not modified as it may be
+// shared with the serving index.
+func (di *DomainIndex) SetAllowlist(allow *DomainIndex) {
+	di.allow = allow
+}
+
+// Suffixes - Will return public suffix list index was built against
+func (di *DomainIndex) Suffixes() *PublicSuffixList {
+	return di.suffixes
+}
+
+// LoadedAt - Will return time index started serving
+func (di *DomainIndex) LoadedAt() time.Time {
+	return di.loadedAt
+}
+
+// FetchedAt - Will return time the oldest of the sources index was built out
+// of was successfully read. Remote sources served from their last good copy
+// keep the time of their last successful fetch, every other source is read
+// at the time index started serving.
+func (di *DomainIndex) FetchedAt() time.Time {
+	if di.fetchedAt.IsZero() || di.fetchedAt.After(di.loadedAt) {
+		return di.loadedAt
+	}
+
+	return di.fetchedAt
+}
+
+// markFetched - Will record remote source index is built out of was last
+// successfully fetched at provided time
+func (di *DomainIndex) markFetched(at time.Time) {
+	if di.fetchedAt.IsZero() || at.Before(di.fetchedAt) {
+		di.fetchedAt = at
+	}
+}
+
+// All - Will return unique normalized domains in the order they were added
+func (di *DomainIndex) All() []string {
+	return di.list
+}
+
+// Len - Will return number of unique domains in the index
+func (di *DomainIndex) Len() int {
+	if di == nil {
+		return 0
+	}
+
+	return len(di.domains)
+}
+
+// NewDomainIndex - Will build new index out of provided domains, one per
+// line. Parent domain matching stops at registrable domain according to
+// provided public suffix list which may be nil.
+func NewDomainIndex(domains []string, suffixes *PublicSuffixList) *DomainIndex {
+	di := &DomainIndex{
+		domains:  make(map[string]indexEntry, len(domains)),
+		list:     make([]string, 0, len(domains)),
+		suffixes: suffixes,
+		also:     map[string][]int{},
+		hash:     sha256.New(),
+	}
+
+	di.AddList(domains, NoFile)
+
+	return di
+}
+
+// NormalizeDomain - Will lowercase domain and strip surrounding whitespace as
+// well as the trailing root dot so that "Mailinator.COM." and "mailinator.com"
+// end up as the same key. Internationalized domains are mapped (UTS-46) to
+// their A-label form so that "müllmail.de" and "xn--mllmail-n2a.de" end up as
+// the same key too. Domains failing to map are only lowercased.
+func NormalizeDomain(domain string) string {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+
+	if !isASCII(domain) {
+		if ascii, err := idna.Lookup.ToASCII(domain); err == nil {
+			return strings.TrimSuffix(ascii, ".")
+		}
+	}
+
+	return domain
+}
+
+// EmailDomain - Will return domain of provided email address with comments
+// stripped. Domain of address that does not parse is everything after its
+// last @ or empty string if there is no @ at all.
+func EmailDomain(email string) string {
+	if address, err := ParseInternationalAddress(email); err == nil {
+		return address.Domain
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return ""
+	}
+
+	return email[at+1:]
+}
+
+// containsFile - Will check if provided file id is in the list
+func containsFile(files []int, file int) bool {
+	for _, f := range files {
+		if f == file {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,294 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	LintInvalidDomain  = "invalid_domain"
+	LintInvalidPattern = "invalid_pattern"
+	LintDuplicate      = "duplicate"
+	LintCovered        = "covered"
+	LintNonPunycode    = "non_punycode"
+	LintAllowlisted    = "allowlisted"
+)
+
+// LintIssue - Single problem found in a list file. Related is the entry (or
+// punycode form of it) the issue is about, along with where it was found.
+type LintIssue struct {
+	Type        string `json:"type"`
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Entry       string `json:"entry"`
+	Related     string `json:"related,omitempty"`
+	RelatedFile string `json:"related_file,omitempty"`
+	RelatedLine int    `json:"related_line,omitempty"`
+	Message     string `json:"message"`
+}
+
+// LintReport - Outcome of linting list files
+type LintReport struct {
+	Files   []ListFile  `json:"files"`
+	Entries int         `json:"entries"`
+	Domains int         `json:"domains"`
+	Issues  []LintIssue `json:"issues"`
+	OK      bool        `json:"ok"`
+}
+
+// linter - Lints entries into an index of its own so that duplicates and
+// covered entries can be told apart by where they were listed.
+type linter struct {
+	index  *DomainIndex
+	report *LintReport
+}
+
+// Lint - Will parse every file of provided sources with the same loader the
+// service uses and report invalid domains, duplicates, entries covered by a
+// parent entry, IDNs not listed as punycode and entries colliding with the
+// allowlist.
+func (de *DisposableEmails) Lint(sources []ListSource) (*LintReport, error) {
+	suffixes, err := LoadPublicSuffixList(de.SuffixesSource)
+	if err != nil {
+		log.Warnf("[lint] Failed to load public suffix list (source: %s) due to (err: %s). Treating last label as public suffix...", de.SuffixesSource, err)
+	}
+
+	l := &linter{
+		index:  NewDomainIndex(nil, suffixes),
+		report: &LintReport{Files: []ListFile{}, Issues: []LintIssue{}},
+	}
+
+	if len(de.AllowSources) > 0 {
+		allow, err := de.loadAllowlist(suffixes)
+		if err != nil {
+			return nil, err
+		}
+
+		l.index.SetAllowlist(allow)
+	}
+
+	for _, source := range sources {
+		err := de.readSource(source, func(file string, data []byte) error {
+			entries, format, err := ParseList(file, data)
+			if err != nil {
+				return fmt.Errorf("could not parse (file: %s) due to (err: %s)", file, err)
+			}
+
+			l.lintFile(ListFile{Source: source.Name, Path: file, Format: format}, entries)
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l.lintIndex()
+	return l.finish(), nil
+}
+
+// lintFile - Will check entries of a single file one by one
+func (l *linter) lintFile(file ListFile, entries []ListEntry) {
+	l.report.Files = append(l.report.Files, file)
+	l.report.Entries += len(entries)
+
+	fileIndex := l.index.AddFile(file)
+
+	for _, entry := range entries {
+		raw := strings.TrimSpace(entry.Domain)
+		domain := NormalizeDomain(strings.TrimPrefix(raw, ExactEntryPrefix))
+		issue := LintIssue{File: file.Path, Line: entry.Line, Entry: raw}
+
+		if IsPattern(raw) {
+			l.lintPattern(issue, fileIndex)
+			continue
+		}
+
+		if !isASCII(raw) && isASCII(domain) {
+			issue.Type, issue.Related = LintNonPunycode, domain
+			issue.Message = fmt.Sprintf("internationalized domain should be listed in punycode as %s", domain)
+			l.add(issue)
+		}
+
+		if domain == "" || DomainSyntaxRule(domain) != "" {
+			issue.Type, issue.Related = LintInvalidDomain, ""
+			issue.Message = fmt.Sprintf("%q is not a valid domain", raw)
+			l.add(issue)
+			continue
+		}
+
+		if !l.index.Add(raw, fileIndex, entry.Line) {
+			origin, _ := l.index.Origin(domain)
+			issue.Type, issue.Related = LintDuplicate, domain
+			issue.RelatedFile, issue.RelatedLine = origin.Path, l.index.Line(domain)
+			issue.Message = fmt.Sprintf("%s is already listed on %s:%d", domain, issue.RelatedFile, issue.RelatedLine)
+			l.add(issue)
+		}
+	}
+}
+
+// lintPattern - Will check that glob or RE2 entry compiles and is not listed
+// already.
+func (l *linter) lintPattern(issue LintIssue, fileIndex int) {
+	rule, err := CompilePattern(issue.Entry)
+	if err != nil {
+		issue.Type = LintInvalidPattern
+		issue.Message = fmt.Sprintf("%q is not a valid pattern: %s", issue.Entry, err)
+		l.add(issue)
+		return
+	}
+
+	if !l.index.Add(issue.Entry, fileIndex, issue.Line) {
+		origin, _ := l.index.Origin(rule.Pattern)
+		issue.Type, issue.Related = LintDuplicate, rule.Pattern
+		issue.RelatedFile, issue.RelatedLine = origin.Path, l.index.Line(rule.Pattern)
+		issue.Message = fmt.Sprintf("%s is already listed on %s:%d", rule.Pattern, issue.RelatedFile, issue.RelatedLine)
+		l.add(issue)
+	}
+}
+
+// lintIndex - Will check entries against each other and the allowlist once
+// every file is indexed.
+func (l *linter) lintIndex() {
+	for _, domain := range l.index.All() {
+		if IsPattern(domain) {
+			continue
+		}
+
+		origin, _ := l.index.Origin(domain)
+		line := l.index.Line(domain)
+
+		if parent, ok := l.index.Parent(domain); ok {
+			parentOrigin, _ := l.index.Origin(parent)
+			l.add(LintIssue{
+				Type:        LintCovered,
+				File:        origin.Path,
+				Line:        line,
+				Entry:       domain,
+				Related:     parent,
+				RelatedFile: parentOrigin.Path,
+				RelatedLine: l.index.Line(parent),
+				Message:     fmt.Sprintf("%s is already covered by %s listed on %s:%d", domain, parent, parentOrigin.Path, l.index.Line(parent)),
+			})
+		}
+
+		if entry, ok := l.index.Allow(domain); ok {
+			allowOrigin, _ := l.index.Allowlist().Origin(entry)
+			l.add(LintIssue{
+				Type:        LintAllowlisted,
+				File:        origin.Path,
+				Line:        line,
+				Entry:       domain,
+				Related:     entry,
+				RelatedFile: allowOrigin.Path,
+				RelatedLine: l.index.Allowlist().Line(entry),
+				Message:     fmt.Sprintf("%s collides with allowlist entry %s of (source: %s)", domain, entry, allowOrigin.Source),
+			})
+		}
+	}
+}
+
+// add - Will record provided issue
+func (l *linter) add(issue LintIssue) {
+	l.report.Issues = append(l.report.Issues, issue)
+}
+
+// finish - Will sort issues by file and line they were found on
+func (l *linter) finish() *LintReport {
+	order := map[string]int{}
+	for i, file := range l.report.Files {
+		if _, ok := order[file.Path]; !ok {
+			order[file.Path] = i
+		}
+	}
+
+	sort.SliceStable(l.report.Issues, func(i, j int) bool {
+		a, b := l.report.Issues[i], l.report.Issues[j]
+		if order[a.File] != order[b.File] {
+			return order[a.File] < order[b.File]
+		}
+		return a.Line < b.Line
+	})
+
+	l.report.Domains = l.index.Len()
+	l.report.OK = len(l.report.Issues) == 0
+	return l.report
+}
+
+// RunLint - Will run `disposable lint [flags] <file>...` writing report to
+// out. Exit code is 0 for clean lists, 1 when issues are found and 2 when
+// lists could not be linted at all.
+func RunLint(args []string, out, errOut io.Writer) int {
+	flags := flag.NewFlagSet("lint", flag.ContinueOnError)
+	flags.SetOutput(errOut)
+
+	asJSON := flags.Bool("json", false, "Write report as JSON")
+	allowlist := flags.String("allowlist", OptionString("DISPOSABLE_EMAILS_ALLOWLIST", ""), "Allowlist sources entries are checked against")
+	suffixes := flags.String("suffixes", OptionString("PUBLIC_SUFFIX_LIST_SOURCE", "services/publicsuffix/public_suffix_list.dat"), "Public suffix list")
+	verbose := flags.Bool("v", false, "Log loading progress")
+
+	flags.Usage = func() {
+		fmt.Fprintf(errOut, "Usage: disposable lint [flags] <file|dir|glob|url>...\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	if !*verbose {
+		log.SetLevel(log.WarnLevel)
+	}
+
+	de, err := NewDisposableEmails()
+	if err != nil {
+		fmt.Fprintf(errOut, "lint: %s\n", err)
+		return 2
+	}
+
+	de.SuffixesSource = *suffixes
+	de.AllowSources = ParseListSources(*allowlist)
+
+	sources := []ListSource{}
+	for _, arg := range flags.Args() {
+		sources = append(sources, ParseListSources(arg)...)
+	}
+
+	report, err := de.Lint(sources)
+	if err != nil {
+		fmt.Fprintf(errOut, "lint: %s\n", err)
+		return 2
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		for _, issue := range report.Issues {
+			fmt.Fprintf(out, "%s:%d: %s: %s\n", issue.File, issue.Line, issue.Type, issue.Message)
+		}
+		fmt.Fprintf(out, "%d files, %d entries, %d domains, %d issues\n", len(report.Files), report.Entries, report.Domains, len(report.Issues))
+	}
+
+	if !report.OK {
+		return 1
+	}
+
+	return 0
+}
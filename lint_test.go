@@ -0,0 +1,109 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// issueTypes - Will return types of reported issues in order
+func issueTypes(report *LintReport) []string {
+	types := []string{}
+	for _, issue := range report.Issues {
+		types = append(types, issue.Type)
+	}
+	return types
+}
+
+func TestLint(t *testing.T) {
+	Convey("Lint reports every kind of issue with where it was found", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		list := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(list, []byte("mailinator.com\ninvalid..com\nguerrillamail.com\nMailinator.com\nmx.mailinator.com\nbücher.example\ngmail.com\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "allow.txt"), []byte("gmail.com\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{AllowSources: []ListSource{{Name: "partners", Path: filepath.Join(dir, "allow.txt")}}}
+		report, err := emails.Lint([]ListSource{{Name: "burner", Path: list}})
+		So(err, ShouldBeNil)
+
+		So(report.OK, ShouldBeFalse)
+		So(report.Entries, ShouldEqual, 7)
+		So(report.Domains, ShouldEqual, 5)
+		So(report.Files, ShouldResemble, []ListFile{{Source: "burner", Path: list, Format: FormatText}})
+		So(issueTypes(report), ShouldResemble, []string{LintInvalidDomain, LintDuplicate, LintCovered, LintNonPunycode, LintAllowlisted})
+
+		So(report.Issues[1].Line, ShouldEqual, 4)
+		So(report.Issues[1].RelatedLine, ShouldEqual, 1)
+		So(report.Issues[2].Entry, ShouldEqual, "mx.mailinator.com")
+		So(report.Issues[2].Related, ShouldEqual, "mailinator.com")
+		So(report.Issues[3].Related, ShouldEqual, "xn--bcher-kva.example")
+		So(report.Issues[4].Related, ShouldEqual, "gmail.com")
+		So(report.Issues[4].RelatedFile, ShouldEqual, filepath.Join(dir, "allow.txt"))
+	})
+
+	Convey("Exact-only parents do not cover subdomains", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("=mailinator.com\nmx.mailinator.com\nmail-*.tld\n"), 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(`["guerrillamail.com"]`), 0644), ShouldBeNil)
+
+		report, err := (&DisposableEmails{}).Lint([]ListSource{{Name: "burner", Path: dir}})
+		So(err, ShouldBeNil)
+		So(report.OK, ShouldBeTrue)
+		So(report.Domains, ShouldEqual, 4)
+		So(report.Issues, ShouldBeEmpty)
+	})
+
+	Convey("Patterns that would be skipped by the loader are reported", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		list := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(list, []byte("*.com\n/^mail-[0-9]+$/\n/^mail-[0-9]+$/\n"), 0644), ShouldBeNil)
+
+		report, err := (&DisposableEmails{}).Lint([]ListSource{{Name: "burner", Path: list}})
+		So(err, ShouldBeNil)
+		So(issueTypes(report), ShouldResemble, []string{LintInvalidPattern, LintDuplicate})
+		So(report.Issues[1].RelatedLine, ShouldEqual, 2)
+	})
+
+	Convey("Lint command writes JSON report and exits non zero on issues", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		list := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(list, []byte("mailinator.com\nmailinator.com\n"), 0644), ShouldBeNil)
+
+		var out, errOut bytes.Buffer
+		So(RunLint([]string{"-json", "-allowlist", "", list}, &out, &errOut), ShouldEqual, 1)
+
+		report := &LintReport{}
+		So(json.Unmarshal(out.Bytes(), report), ShouldBeNil)
+		So(issueTypes(report), ShouldResemble, []string{LintDuplicate})
+		So(report.Issues[0].File, ShouldEqual, list)
+
+		So(ioutil.WriteFile(list, []byte("mailinator.com\n"), 0644), ShouldBeNil)
+		out.Reset()
+		So(RunLint([]string{"-allowlist", "", list}, &out, &errOut), ShouldEqual, 0)
+		So(out.String(), ShouldEqual, "1 files, 1 entries, 1 domains, 0 issues\n")
+
+		So(RunLint([]string{filepath.Join(dir, "missing.txt")}, &out, &errOut), ShouldEqual, 2)
+		So(RunLint([]string{}, &out, &errOut), ShouldEqual, 2)
+	})
+}
@@ -4,7 +4,11 @@
 
 package main
 
-import log "github.com/Sirupsen/logrus"
+import (
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+)
 
 var (
 	service *Service
@@ -13,6 +17,10 @@ var (
 func main() {
 	var err error
 
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		os.Exit(RunLint(os.Args[2:], os.Stdout, os.Stderr))
+	}
+
 	if service, err = New(); err != nil {
 		log.Fatalf("Failed to initiate new service (err: %s)", err)
 	}
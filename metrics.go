@@ -0,0 +1,22 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import "expvar"
+
+// Metrics are published through expvar and served under /debug/vars
+var (
+	metricListLoads           = expvar.NewInt("list_loads")
+	metricListLoadErrors      = expvar.NewInt("list_load_errors")
+	metricListDomains         = expvar.NewInt("list_domains")
+	metricListPreviousDomains = expvar.NewInt("list_previous_domains")
+	metricListAllowedDomains  = expvar.NewInt("list_allowed_domains")
+	metricRemoteFetches       = expvar.NewInt("remote_fetches")
+	metricRemoteFetchErrors   = expvar.NewInt("remote_fetch_errors")
+	metricRemoteNotModified   = expvar.NewInt("remote_not_modified")
+	metricListSignatureErrors = expvar.NewInt("list_signature_errors")
+	metricListRollbacks       = expvar.NewInt("list_rollbacks")
+	metricListPatternErrors   = expvar.NewInt("list_pattern_errors")
+)
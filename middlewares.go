@@ -97,33 +97,65 @@ func BasicAuth(h http.HandlerFunc) http.HandlerFunc {
 		username := OptionString("HTTP_BASIC_USERNAME", "disposable")
 		password := OptionString("HTTP_BASIC_PASSWORD", "disposable123")
 
-		w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
-
-		s := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
-		if len(s) != 2 {
-			http.Error(w, "Not authorized", 401)
-			return
+		if checkBasicAuth(w, r, username, password) {
+			h.ServeHTTP(w, r)
 		}
+	}
+}
 
-		b, err := base64.StdEncoding.DecodeString(s[1])
-		if err != nil {
-			http.Error(w, err.Error(), 401)
-			return
-		}
+// AdminCredentials - Will return credentials admin endpoints are protected
+// with. Admin endpoints are disabled unless both are set.
+func AdminCredentials() (string, string) {
+	return OptionString("ADMIN_USERNAME", ""), OptionString("ADMIN_PASSWORD", "")
+}
+
+// AdminAuth - Will require admin credentials, separate from the ones
+// verification endpoints are protected with
+func AdminAuth(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		log.Infof("[admin_auth] Setting up middleware...")
+		username, password := AdminCredentials()
 
-		pair := strings.SplitN(string(b), ":", 2)
-		if len(pair) != 2 {
+		if username == "" || password == "" {
 			http.Error(w, "Not authorized", 401)
 			return
 		}
 
-		if pair[0] != username || pair[1] != password {
-			http.Error(w, "Not authorized", 401)
-			return
+		if checkBasicAuth(w, r, username, password) {
+			h.ServeHTTP(w, r)
 		}
+	}
+}
 
-		h.ServeHTTP(w, r)
+// checkBasicAuth - Will check if request carries provided basic auth
+// credentials and respond with 401 if it does not
+func checkBasicAuth(w http.ResponseWriter, r *http.Request, username, password string) bool {
+	w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+
+	s := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+	if len(s) != 2 {
+		http.Error(w, "Not authorized", 401)
+		return false
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s[1])
+	if err != nil {
+		http.Error(w, err.Error(), 401)
+		return false
+	}
+
+	pair := strings.SplitN(string(b), ":", 2)
+	if len(pair) != 2 {
+		http.Error(w, "Not authorized", 401)
+		return false
 	}
+
+	if pair[0] != username || pair[1] != password {
+		http.Error(w, "Not authorized", 401)
+		return false
+	}
+
+	return true
 }
 
 func CompressionHandler(h http.HandlerFunc) http.HandlerFunc {
@@ -0,0 +1,193 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	NormalizeLowercase  = "lowercase"
+	NormalizeSubaddress = "subaddress"
+	NormalizeDots       = "dots"
+	NormalizeAlias      = "alias"
+)
+
+// embeddedProviderRules - Provider rules compiled into the binary. Served
+// unless PROVIDER_RULES_SOURCE points to another rules file.
+//
+//go:embed assets/providers.json
+var embeddedProviderRules []byte
+
+// ProviderRule - How a mail provider maps addresses onto mailboxes
+type ProviderRule struct {
+	Name string `json:"name"`
+
+	// Domains - Domains provider hosts mailboxes on
+	Domains []string `json:"domains,omitempty"`
+
+	// CanonicalDomain - Domain every one of Domains is an alias of, if any
+	CanonicalDomain string `json:"canonical_domain,omitempty"`
+
+	// Subaddress - Characters separating mailbox from subaddress (tag) in
+	// local part, e.g. "+" for "john+news"
+	Subaddress string `json:"subaddress,omitempty"`
+
+	// IgnoreDots - Dots in local part make no difference
+	IgnoreDots bool `json:"ignore_dots,omitempty"`
+
+	// CaseSensitive - Case of local part makes a difference
+	CaseSensitive bool `json:"case_sensitive,omitempty"`
+}
+
+// DefaultProviderRule - Rule applied to addresses of domains no known provider
+// hosts unless rules say otherwise. Only domain is normalized, nothing is
+// known about how such domains map local parts onto mailboxes.
+var DefaultProviderRule = ProviderRule{Name: "default", CaseSensitive: true}
+
+// ProviderRules - Rules of known mail providers keyed by their domains along
+// with default rule applied to addresses of any other domain
+type ProviderRules struct {
+	Default   ProviderRule   `json:"default"`
+	Providers []ProviderRule `json:"providers"`
+
+	domains map[string]*ProviderRule
+}
+
+// CanonicalAddress - Canonical mailbox of an address along with provider rule
+// that mapped it and normalizations that changed it
+type CanonicalAddress struct {
+	Address  string
+	Provider string
+	Rules    []string
+}
+
+// ParseProviderRules - Will parse provider rules out of JSON document
+func ParseProviderRules(data []byte) (*ProviderRules, error) {
+	pr := &ProviderRules{Default: DefaultProviderRule}
+	if err := json.Unmarshal(data, pr); err != nil {
+		return nil, err
+	}
+
+	pr.domains = map[string]*ProviderRule{}
+
+	for i := range pr.Providers {
+		provider := &pr.Providers[i]
+		provider.CanonicalDomain = NormalizeDomain(provider.CanonicalDomain)
+
+		for _, domain := range provider.Domains {
+			pr.domains[NormalizeDomain(domain)] = provider
+		}
+	}
+
+	return pr, nil
+}
+
+// LoadProviderRules - Will load and parse provider rules from file
+func LoadProviderRules(source string) (*ProviderRules, error) {
+	data, err := ioutil.ReadFile(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseProviderRules(data)
+}
+
+// NewEmbeddedProviderRules - Will parse provider rules embedded into the
+// binary
+func NewEmbeddedProviderRules() *ProviderRules {
+	pr, err := ParseProviderRules(embeddedProviderRules)
+	if err != nil {
+		log.Errorf("[provider_rules] Could not load embedded provider rules due to (err: %s)", err)
+		return &ProviderRules{Default: DefaultProviderRule, domains: map[string]*ProviderRule{}}
+	}
+
+	return pr
+}
+
+// NewProviderRulesFromEnv - Will load provider rules file PROVIDER_RULES_SOURCE
+// points to. Embedded rules are served when it is not set or can not be
+// loaded.
+func NewProviderRulesFromEnv() *ProviderRules {
+	source := OptionString("PROVIDER_RULES_SOURCE", "")
+	if source == "" {
+		return NewEmbeddedProviderRules()
+	}
+
+	pr, err := LoadProviderRules(source)
+	if err != nil {
+		log.Warnf("[provider_rules] Failed to load provider rules (source: %s) due to (err: %s). Falling back to embedded rules...", source, err)
+		return NewEmbeddedProviderRules()
+	}
+
+	log.Infof("[provider_rules] Loaded (providers: %d) from (source: %s)", len(pr.Providers), source)
+	return pr
+}
+
+// Provider - Will return rule of provider hosting provided domain or default
+// rule if no known provider does. Nil rules know no providers and apply
+// DefaultProviderRule.
+func (pr *ProviderRules) Provider(domain string) *ProviderRule {
+	if pr == nil {
+		rule := DefaultProviderRule
+		return &rule
+	}
+
+	if provider, ok := pr.domains[NormalizeDomain(domain)]; ok {
+		return provider
+	}
+
+	return &pr.Default
+}
+
+// Normalize - Will return canonical mailbox of provided address. Domain is
+// always normalized (lowercased, A-label), local part and domain aliases are
+// mapped the way provider rule says. Local part of domains no known provider
+// hosts is kept as it is by default. Quoted local parts are kept as they are.
+func (pr *ProviderRules) Normalize(address *Address) *CanonicalAddress {
+	provider := pr.Provider(address.Domain)
+	canonical := &CanonicalAddress{Provider: provider.Name, Rules: []string{}}
+
+	local, domain := address.LocalPart, address.Domain
+	apply := func(rule, normalized string, value *string) {
+		if normalized == *value {
+			return
+		}
+
+		if !StringInSlice(rule, canonical.Rules) {
+			canonical.Rules = append(canonical.Rules, rule)
+		}
+
+		*value = normalized
+	}
+
+	apply(NormalizeLowercase, NormalizeDomain(domain), &domain)
+
+	if !address.Quoted() {
+		if !provider.CaseSensitive {
+			apply(NormalizeLowercase, strings.ToLower(local), &local)
+		}
+
+		if cut := strings.IndexAny(local, provider.Subaddress); provider.Subaddress != "" && cut > 0 {
+			apply(NormalizeSubaddress, local[:cut], &local)
+		}
+
+		if provider.IgnoreDots {
+			apply(NormalizeDots, strings.Replace(local, ".", "", -1), &local)
+		}
+	}
+
+	if provider.CanonicalDomain != "" {
+		apply(NormalizeAlias, provider.CanonicalDomain, &domain)
+	}
+
+	canonical.Address = local + "@" + domain
+	return canonical
+}
@@ -0,0 +1,78 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProviderRules(t *testing.T) {
+	rules := NewEmbeddedProviderRules()
+
+	canonical := func(email string) string {
+		address, err := ParseInternationalAddress(email)
+		So(err, ShouldBeNil)
+		return rules.Normalize(address).Address
+	}
+
+	Convey("Embedded rules know popular providers", t, func() {
+		So(len(rules.Providers), ShouldBeGreaterThan, 0)
+		So(rules.Provider("GoogleMail.com").Name, ShouldEqual, "gmail")
+		So(rules.Provider("me.com").Name, ShouldEqual, "icloud")
+		So(rules.Provider("example.com").Name, ShouldEqual, "default")
+	})
+
+	Convey("Addresses are mapped onto provider mailboxes", t, func() {
+		for email, mailbox := range map[string]string{
+			"john.doe@gmail.com":             "johndoe@gmail.com",
+			"J.O.H.N.Doe+a+b@GoogleMail.com": "johndoe@gmail.com",
+			"john.doe+news@outlook.com":      "john.doe@outlook.com",
+			"john.doe-news@yahoo.com":        "john.doe@yahoo.com",
+			"john+news@yahoo.com":            "john+news@yahoo.com",
+			"john+news@mac.com":              "john@icloud.com",
+			"john@pm.me":                     "john@proton.me",
+			"John.Doe+news@Example.COM":      "John.Doe+news@example.com",
+			"+news@example.com":              "+news@example.com",
+			`"John+Doe"@gmail.com`:           `"John+Doe"@gmail.com`,
+			"john@[192.168.0.1]":             "john@[192.168.0.1]",
+			"jöhn+x@Müller.de":               "jöhn+x@xn--mller-kva.de",
+		} {
+			So(canonical(email), ShouldEqual, mailbox)
+		}
+	})
+
+	Convey("Only rules that changed the address are reported", t, func() {
+		address, _ := ParseAddress("john@gmail.com")
+		So(rules.Normalize(address).Rules, ShouldBeEmpty)
+
+		address, _ = ParseAddress("John@Example.com")
+		So(rules.Normalize(address).Rules, ShouldResemble, []string{NormalizeLowercase})
+		So(rules.Normalize(address).Address, ShouldEqual, "John@example.com")
+	})
+
+	Convey("Rules are read from JSON document", t, func() {
+		custom, err := ParseProviderRules([]byte(`{"default": {"name": "other", "case_sensitive": false, "subaddress": "+"}, "providers": [{"name": "corp", "domains": ["Corp.example"], "canonical_domain": "corp.example", "subaddress": "+-"}]}`))
+		So(err, ShouldBeNil)
+
+		address, _ := ParseAddress("John-Sales@corp.example")
+		So(custom.Normalize(address).Address, ShouldEqual, "john@corp.example")
+
+		address, _ = ParseAddress("John+x@other.example")
+		So(custom.Normalize(address).Address, ShouldEqual, "john@other.example")
+
+		omitted, err := ParseProviderRules([]byte(`{"providers": []}`))
+		So(err, ShouldBeNil)
+		So(omitted.Normalize(address).Address, ShouldEqual, "John+x@other.example")
+
+		_, err = ParseProviderRules([]byte(`{"providers": {}}`))
+		So(err, ShouldNotBeNil)
+
+		var none *ProviderRules
+		address, _ = ParseAddress("John+x@Other.example")
+		So(none.Normalize(address).Address, ShouldEqual, "John+x@other.example")
+	})
+}
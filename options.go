@@ -7,6 +7,7 @@ package main
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // OptionString - Will return ENV value back based on provided name casted as string
@@ -49,3 +50,20 @@ func OptionInt(name string, def int) int {
 
 	return b
 }
+
+// OptionDuration - Will return ENV value back based on provided name parsed as
+// duration (e.g. "90s", "48h")
+func OptionDuration(name string, def time.Duration) time.Duration {
+	var res string
+
+	if res = os.Getenv(name); res == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(res)
+	if err != nil {
+		return def
+	}
+
+	return d
+}
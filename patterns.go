@@ -0,0 +1,179 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Kinds of list rules a domain can be matched by
+const (
+	RuleExact  = "exact"
+	RuleSuffix = "suffix"
+	RuleGlob   = "glob"
+	RuleRegex  = "regex"
+)
+
+const (
+	// MaxPatternLength - Longer patterns are rejected
+	MaxPatternLength = 255
+
+	// MaxPatternInstructions - Patterns compiling into bigger programs are
+	// rejected. RE2 matches in linear time but program size still multiplies
+	// cost of every lookup.
+	MaxPatternInstructions = 1000
+)
+
+// patternProbes - Pattern matching any of these is too broad to be a list
+// entry and is rejected
+var patternProbes = []string{"", "com", "gmail.com", "outlook.com", "yahoo.com", "example.com", "mail.example.co.uk"}
+
+// PatternRule - Glob or RE2 list entry compiled once at load. Globs are
+// entries with * (any run of characters within a label), ? (any single
+// character) or [...] classes. RE2 patterns are entries wrapped in slashes
+// such as /^mail-[0-9]+\.tld$/.
+type PatternRule struct {
+	Pattern string
+	Kind    string
+	re      *regexp.Regexp
+}
+
+// MatchString - Will check if provided normalized domain matches the rule
+func (pr *PatternRule) MatchString(domain string) bool {
+	return pr.re.MatchString(domain)
+}
+
+// IsPattern - Will check if provided list entry is a glob or RE2 pattern
+// rather than a domain.
+func IsPattern(entry string) bool {
+	return isRegexEntry(entry) || strings.ContainsAny(entry, "*?[")
+}
+
+// isRegexEntry - Will check if provided list entry is wrapped in slashes
+func isRegexEntry(entry string) bool {
+	return len(entry) > 2 && strings.HasPrefix(entry, "/") && strings.HasSuffix(entry, "/")
+}
+
+// PatternKind - Will return kind of provided pattern list entry
+func PatternKind(entry string) string {
+	if isRegexEntry(entry) {
+		return RuleRegex
+	}
+
+	return RuleGlob
+}
+
+// CompilePattern - Will compile provided glob or RE2 list entry. Patterns that
+// are too long, too complex or match well known domains are rejected.
+func CompilePattern(entry string) (*PatternRule, error) {
+	entry = strings.TrimSpace(entry)
+
+	if len(entry) > MaxPatternLength {
+		return nil, fmt.Errorf("pattern longer than (max: %d) characters", MaxPatternLength)
+	}
+
+	rule := &PatternRule{Pattern: entry, Kind: PatternKind(entry)}
+
+	var expr string
+	if rule.Kind == RuleRegex {
+		expr = entry[1 : len(entry)-1]
+	} else {
+		rule.Pattern = NormalizeDomain(entry)
+		expr = globExpr(rule.Pattern)
+	}
+
+	parsed, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prog.Inst) > MaxPatternInstructions {
+		return nil, fmt.Errorf("pattern compiles into (instructions: %d) over (max: %d)", len(prog.Inst), MaxPatternInstructions)
+	}
+
+	if rule.re, err = regexp.Compile(expr); err != nil {
+		return nil, err
+	}
+
+	for _, probe := range patternProbes {
+		if rule.re.MatchString(probe) {
+			return nil, fmt.Errorf("pattern is too broad as it matches (domain: %q)", probe)
+		}
+	}
+
+	return rule, nil
+}
+
+// globExpr - Will translate glob into anchored regular expression. Wildcards
+// and negated classes never cross label boundaries.
+func globExpr(glob string) string {
+	var expr strings.Builder
+	expr.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			expr.WriteString("[^.]*")
+		case '?':
+			expr.WriteString("[^.]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(regexp.QuoteMeta(glob[i:]))
+				i = len(glob)
+				continue
+			}
+
+			// Class contents are literal characters and ranges. Negated
+			// classes never match a dot so they stay within a label too.
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				expr.WriteString("[^." + regexp.QuoteMeta(class[1:]) + "]")
+			} else {
+				expr.WriteString("[" + regexp.QuoteMeta(class) + "]")
+			}
+
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr.WriteString("$")
+	return expr.String()
+}
+
+// MatchRule - Will return kind of rule provided list entry matched provided
+// domain by.
+func MatchRule(domain, entry string) string {
+	switch {
+	case entry == "":
+		return ""
+	case IsPattern(entry):
+		return PatternKind(entry)
+	case NormalizeDomain(domain) == entry:
+		return RuleExact
+	}
+
+	return RuleSuffix
+}
+
+// entryKey - Will return key list entry is indexed under. Domains are
+// normalized, patterns are kept as written apart from globs being lowercased.
+func entryKey(entry string) string {
+	if entry = strings.TrimSpace(entry); isRegexEntry(entry) {
+		return entry
+	}
+
+	return NormalizeDomain(entry)
+}
@@ -0,0 +1,102 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPatterns(t *testing.T) {
+	Convey("Glob wildcards stay within a single label", t, func() {
+		rule, err := CompilePattern("Mail-*.TLD")
+		So(err, ShouldBeNil)
+		So(rule.Kind, ShouldEqual, RuleGlob)
+		So(rule.Pattern, ShouldEqual, "mail-*.tld")
+
+		So(rule.MatchString("mail-123.tld"), ShouldBeTrue)
+		So(rule.MatchString("mail-.tld"), ShouldBeTrue)
+		So(rule.MatchString("mail-1.x.tld"), ShouldBeFalse)
+		So(rule.MatchString("gmail-1.tld"), ShouldBeFalse)
+
+		rule, err = CompilePattern("mx?[0-9][!a].tempmail.xyz")
+		So(err, ShouldBeNil)
+		So(rule.MatchString("mxa5b.tempmail.xyz"), ShouldBeTrue)
+		So(rule.MatchString("mxa5a.tempmail.xyz"), ShouldBeFalse)
+		So(rule.MatchString("mx5b.tempmail.xyz"), ShouldBeFalse)
+	})
+
+	Convey("Glob classes are literal and negated ones stay within a label", t, func() {
+		rule, err := CompilePattern("mail[!x].tld")
+		So(err, ShouldBeNil)
+		So(rule.MatchString("mailz.tld"), ShouldBeTrue)
+		So(rule.MatchString("mail..tld"), ShouldBeFalse)
+		So(rule.MatchString("mailx.tld"), ShouldBeFalse)
+
+		rule, err = CompilePattern("tmp*.mail[!0-9]io")
+		So(err, ShouldBeNil)
+		So(rule.MatchString("tmp1.mailxio"), ShouldBeTrue)
+		So(rule.MatchString("tmp1.mail.io"), ShouldBeFalse)
+
+		rule, err = CompilePattern(`mx[\w].tld`)
+		So(err, ShouldBeNil)
+		So(rule.MatchString("mxw.tld"), ShouldBeTrue)
+		So(rule.MatchString("mxa.tld"), ShouldBeFalse)
+
+		rule, err = CompilePattern("mx[^a].tld")
+		So(err, ShouldBeNil)
+		So(rule.MatchString("mx^.tld"), ShouldBeTrue)
+		So(rule.MatchString("mxb.tld"), ShouldBeFalse)
+	})
+
+	Convey("RE2 patterns are wrapped in slashes", t, func() {
+		rule, err := CompilePattern(`/^mail-[0-9]+\.tld$/`)
+		So(err, ShouldBeNil)
+		So(rule.Kind, ShouldEqual, RuleRegex)
+		So(rule.MatchString("mail-42.tld"), ShouldBeTrue)
+		So(rule.MatchString("mail-x.tld"), ShouldBeFalse)
+
+		So(IsPattern("mailinator.com"), ShouldBeFalse)
+		So(IsPattern("/"), ShouldBeFalse)
+	})
+
+	Convey("Broad, complex and invalid patterns are rejected", t, func() {
+		for _, pattern := range []string{"*", "*.com", "/.*/", "/mail/", "/(/", "/" + strings.Repeat("a", MaxPatternLength) + "/", "/^(a{1,100}){1,100}$/"} {
+			_, err := CompilePattern(pattern)
+			So(err, ShouldNotBeNil)
+		}
+	})
+
+	Convey("Patterns match after domain entries and report themselves", t, func() {
+		index := NewDomainIndex(nil, nil)
+		file := index.AddFile(ListFile{Source: "burner", Path: "patterns.txt"})
+		So(index.AddList([]string{"mailinator.com", "mail-*.tld", `/^[a-z0-9]{12}\.tempmail\.xyz$/`, "*", "mail-*.TLD"}, file), ShouldEqual, 3)
+
+		entry, ok := index.Match("mail-7.tld")
+		So(ok, ShouldBeTrue)
+		So(entry, ShouldEqual, "mail-*.tld")
+		So(MatchRule("mail-7.tld", entry), ShouldEqual, RuleGlob)
+		So(index.Line(entry), ShouldEqual, 2)
+		So(index.Provenance(entry), ShouldResemble, []string{"burner"})
+
+		entry, ok = index.Match("mx.mail-7.tld")
+		So(ok, ShouldBeTrue)
+		So(entry, ShouldEqual, "mail-*.tld")
+
+		entry, ok = index.Match("abcdef123456.tempmail.xyz")
+		So(ok, ShouldBeTrue)
+		So(MatchRule("abcdef123456.tempmail.xyz", entry), ShouldEqual, RuleRegex)
+		So(index.Line(entry), ShouldEqual, 3)
+
+		entry, _ = index.Match("mx.mailinator.com")
+		So(MatchRule("mx.mailinator.com", entry), ShouldEqual, RuleSuffix)
+		So(MatchRule("mailinator.com", "mailinator.com"), ShouldEqual, RuleExact)
+
+		_, ok = index.Match("gmail.com")
+		So(ok, ShouldBeFalse)
+	})
+}
@@ -13,6 +13,16 @@ It has these top-level messages:
 	Error
 	DisposableRequest
 	DisposableResponse
+	DisposableExplanation
+	DisposableCheck
+	DisposableBatchRequest
+	DisposableBatchResponse
+	DisposableStreamRequest
+	DisposableStreamResponse
+	DisposableDomainRequest
+	DisposableDomainResponse
+	DisposableNormalizeRequest
+	DisposableNormalizeResponse
 */
 package disposable
 
@@ -19,7 +19,10 @@ var _ = fmt.Errorf
 var _ = math.Inf
 
 type DisposableRequest struct {
-	Email string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+	Email       string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+	Explain     bool   `protobuf:"varint,2,opt,name=explain" json:"explain"`
+	ListVersion string `protobuf:"bytes,3,opt,name=list_version,json=listVersion" json:"list_version,omitempty"`
+	At          string `protobuf:"bytes,4,opt,name=at" json:"at,omitempty"`
 }
 
 func (m *DisposableRequest) Reset()                    { *m = DisposableRequest{} }
@@ -28,9 +31,20 @@ func (*DisposableRequest) ProtoMessage()               {}
 func (*DisposableRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{0} }
 
 type DisposableResponse struct {
-	Status    bool   `protobuf:"varint,1,opt,name=status" json:"status"`
-	RequestId string `protobuf:"bytes,2,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
-	Error     *Error `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Status            bool                   `protobuf:"varint,1,opt,name=status" json:"status"`
+	RequestId         string                 `protobuf:"bytes,2,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Error             *Error                 `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	MatchedDomain     string                 `protobuf:"bytes,4,opt,name=matched_domain,json=matchedDomain" json:"matched_domain,omitempty"`
+	Domain            string                 `protobuf:"bytes,5,opt,name=domain" json:"domain,omitempty"`
+	RegistrableDomain string                 `protobuf:"bytes,6,opt,name=registrable_domain,json=registrableDomain" json:"registrable_domain,omitempty"`
+	Explanation       *DisposableExplanation `protobuf:"bytes,7,opt,name=explanation" json:"explanation,omitempty"`
+	Sources           []string               `protobuf:"bytes,8,rep,name=sources" json:"sources,omitempty"`
+	Override          bool                   `protobuf:"varint,9,opt,name=override" json:"override"`
+	AllowedDomain     string                 `protobuf:"bytes,10,opt,name=allowed_domain,json=allowedDomain" json:"allowed_domain,omitempty"`
+	ListVersion       string                 `protobuf:"bytes,11,opt,name=list_version,json=listVersion" json:"list_version,omitempty"`
+	MatchedRule       string                 `protobuf:"bytes,12,opt,name=matched_rule,json=matchedRule" json:"matched_rule,omitempty"`
+	DidYouMean        string                 `protobuf:"bytes,13,opt,name=did_you_mean,json=didYouMean" json:"did_you_mean,omitempty"`
+	Canonical         string                 `protobuf:"bytes,14,opt,name=canonical" json:"canonical,omitempty"`
 }
 
 func (m *DisposableResponse) Reset()                    { *m = DisposableResponse{} }
@@ -45,9 +59,187 @@ func (m *DisposableResponse) GetError() *Error {
 	return nil
 }
 
+func (m *DisposableResponse) GetExplanation() *DisposableExplanation {
+	if m != nil {
+		return m.Explanation
+	}
+	return nil
+}
+
+type DisposableExplanation struct {
+	ListVersion string             `protobuf:"bytes,1,opt,name=list_version,json=listVersion" json:"list_version,omitempty"`
+	Checks      []*DisposableCheck `protobuf:"bytes,2,rep,name=checks" json:"checks,omitempty"`
+}
+
+func (m *DisposableExplanation) Reset()                    { *m = DisposableExplanation{} }
+func (m *DisposableExplanation) String() string            { return proto.CompactTextString(m) }
+func (*DisposableExplanation) ProtoMessage()               {}
+func (*DisposableExplanation) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{2} }
+
+func (m *DisposableExplanation) GetChecks() []*DisposableCheck {
+	if m != nil {
+		return m.Checks
+	}
+	return nil
+}
+
+type DisposableCheck struct {
+	Name          string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Passed        bool   `protobuf:"varint,2,opt,name=passed" json:"passed"`
+	Rule          string `protobuf:"bytes,3,opt,name=rule" json:"rule,omitempty"`
+	List          string `protobuf:"bytes,4,opt,name=list" json:"list,omitempty"`
+	MatchedDomain string `protobuf:"bytes,5,opt,name=matched_domain,json=matchedDomain" json:"matched_domain,omitempty"`
+	Source        string `protobuf:"bytes,6,opt,name=source" json:"source,omitempty"`
+	Line          int32  `protobuf:"varint,7,opt,name=line" json:"line,omitempty"`
+	File          string `protobuf:"bytes,8,opt,name=file" json:"file,omitempty"`
+}
+
+func (m *DisposableCheck) Reset()                    { *m = DisposableCheck{} }
+func (m *DisposableCheck) String() string            { return proto.CompactTextString(m) }
+func (*DisposableCheck) ProtoMessage()               {}
+func (*DisposableCheck) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{3} }
+
+type DisposableBatchRequest struct {
+	Emails []string `protobuf:"bytes,1,rep,name=emails" json:"emails,omitempty"`
+}
+
+func (m *DisposableBatchRequest) Reset()                    { *m = DisposableBatchRequest{} }
+func (m *DisposableBatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*DisposableBatchRequest) ProtoMessage()               {}
+func (*DisposableBatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{4} }
+
+type DisposableBatchResponse struct {
+	Status    bool                  `protobuf:"varint,1,opt,name=status" json:"status"`
+	RequestId string                `protobuf:"bytes,2,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Error     *Error                `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Results   []*DisposableResponse `protobuf:"bytes,4,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *DisposableBatchResponse) Reset()                    { *m = DisposableBatchResponse{} }
+func (m *DisposableBatchResponse) String() string            { return proto.CompactTextString(m) }
+func (*DisposableBatchResponse) ProtoMessage()               {}
+func (*DisposableBatchResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{5} }
+
+func (m *DisposableBatchResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *DisposableBatchResponse) GetResults() []*DisposableResponse {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type DisposableStreamRequest struct {
+	Id    string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Email string `protobuf:"bytes,2,opt,name=email" json:"email,omitempty"`
+}
+
+func (m *DisposableStreamRequest) Reset()                    { *m = DisposableStreamRequest{} }
+func (m *DisposableStreamRequest) String() string            { return proto.CompactTextString(m) }
+func (*DisposableStreamRequest) ProtoMessage()               {}
+func (*DisposableStreamRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{6} }
+
+type DisposableStreamResponse struct {
+	Id     string              `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Result *DisposableResponse `protobuf:"bytes,2,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *DisposableStreamResponse) Reset()                    { *m = DisposableStreamResponse{} }
+func (m *DisposableStreamResponse) String() string            { return proto.CompactTextString(m) }
+func (*DisposableStreamResponse) ProtoMessage()               {}
+func (*DisposableStreamResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{7} }
+
+func (m *DisposableStreamResponse) GetResult() *DisposableResponse {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+type DisposableDomainRequest struct {
+	Domain string `protobuf:"bytes,1,opt,name=domain" json:"domain,omitempty"`
+}
+
+func (m *DisposableDomainRequest) Reset()                    { *m = DisposableDomainRequest{} }
+func (m *DisposableDomainRequest) String() string            { return proto.CompactTextString(m) }
+func (*DisposableDomainRequest) ProtoMessage()               {}
+func (*DisposableDomainRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{8} }
+
+type DisposableDomainResponse struct {
+	Status            bool     `protobuf:"varint,1,opt,name=status" json:"status"`
+	RequestId         string   `protobuf:"bytes,2,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Error             *Error   `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Listed            bool     `protobuf:"varint,4,opt,name=listed" json:"listed"`
+	Domain            string   `protobuf:"bytes,5,opt,name=domain" json:"domain,omitempty"`
+	RegistrableDomain string   `protobuf:"bytes,6,opt,name=registrable_domain,json=registrableDomain" json:"registrable_domain,omitempty"`
+	MatchedDomain     string   `protobuf:"bytes,7,opt,name=matched_domain,json=matchedDomain" json:"matched_domain,omitempty"`
+	Sources           []string `protobuf:"bytes,8,rep,name=sources" json:"sources,omitempty"`
+	Override          bool     `protobuf:"varint,9,opt,name=override" json:"override"`
+	AllowedDomain     string   `protobuf:"bytes,10,opt,name=allowed_domain,json=allowedDomain" json:"allowed_domain,omitempty"`
+	MatchedRule       string   `protobuf:"bytes,11,opt,name=matched_rule,json=matchedRule" json:"matched_rule,omitempty"`
+}
+
+func (m *DisposableDomainResponse) Reset()                    { *m = DisposableDomainResponse{} }
+func (m *DisposableDomainResponse) String() string            { return proto.CompactTextString(m) }
+func (*DisposableDomainResponse) ProtoMessage()               {}
+func (*DisposableDomainResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{9} }
+
+func (m *DisposableDomainResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+type DisposableNormalizeRequest struct {
+	Email string `protobuf:"bytes,1,opt,name=email" json:"email,omitempty"`
+}
+
+func (m *DisposableNormalizeRequest) Reset()                    { *m = DisposableNormalizeRequest{} }
+func (m *DisposableNormalizeRequest) String() string            { return proto.CompactTextString(m) }
+func (*DisposableNormalizeRequest) ProtoMessage()               {}
+func (*DisposableNormalizeRequest) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{10} }
+
+type DisposableNormalizeResponse struct {
+	Status    bool     `protobuf:"varint,1,opt,name=status" json:"status"`
+	RequestId string   `protobuf:"bytes,2,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	Error     *Error   `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	Email     string   `protobuf:"bytes,4,opt,name=email" json:"email,omitempty"`
+	Canonical string   `protobuf:"bytes,5,opt,name=canonical" json:"canonical,omitempty"`
+	Provider  string   `protobuf:"bytes,6,opt,name=provider" json:"provider,omitempty"`
+	Rules     []string `protobuf:"bytes,7,rep,name=rules" json:"rules,omitempty"`
+}
+
+func (m *DisposableNormalizeResponse) Reset()                    { *m = DisposableNormalizeResponse{} }
+func (m *DisposableNormalizeResponse) String() string            { return proto.CompactTextString(m) }
+func (*DisposableNormalizeResponse) ProtoMessage()               {}
+func (*DisposableNormalizeResponse) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{11} }
+
+func (m *DisposableNormalizeResponse) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DisposableRequest)(nil), "disposable.DisposableRequest")
 	proto.RegisterType((*DisposableResponse)(nil), "disposable.DisposableResponse")
+	proto.RegisterType((*DisposableExplanation)(nil), "disposable.DisposableExplanation")
+	proto.RegisterType((*DisposableCheck)(nil), "disposable.DisposableCheck")
+	proto.RegisterType((*DisposableBatchRequest)(nil), "disposable.DisposableBatchRequest")
+	proto.RegisterType((*DisposableBatchResponse)(nil), "disposable.DisposableBatchResponse")
+	proto.RegisterType((*DisposableStreamRequest)(nil), "disposable.DisposableStreamRequest")
+	proto.RegisterType((*DisposableStreamResponse)(nil), "disposable.DisposableStreamResponse")
+	proto.RegisterType((*DisposableDomainRequest)(nil), "disposable.DisposableDomainRequest")
+	proto.RegisterType((*DisposableDomainResponse)(nil), "disposable.DisposableDomainResponse")
+	proto.RegisterType((*DisposableNormalizeRequest)(nil), "disposable.DisposableNormalizeRequest")
+	proto.RegisterType((*DisposableNormalizeResponse)(nil), "disposable.DisposableNormalizeResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -62,6 +254,10 @@ const _ = grpc.SupportPackageIsVersion4
 
 type DisposableServiceClient interface {
 	Verify(ctx context.Context, in *DisposableRequest, opts ...grpc.CallOption) (*DisposableResponse, error)
+	VerifyBatch(ctx context.Context, in *DisposableBatchRequest, opts ...grpc.CallOption) (*DisposableBatchResponse, error)
+	VerifyStream(ctx context.Context, opts ...grpc.CallOption) (DisposableService_VerifyStreamClient, error)
+	CheckDomain(ctx context.Context, in *DisposableDomainRequest, opts ...grpc.CallOption) (*DisposableDomainResponse, error)
+	Normalize(ctx context.Context, in *DisposableNormalizeRequest, opts ...grpc.CallOption) (*DisposableNormalizeResponse, error)
 }
 
 type disposableServiceClient struct {
@@ -81,10 +277,72 @@ func (c *disposableServiceClient) Verify(ctx context.Context, in *DisposableRequ
 	return out, nil
 }
 
+func (c *disposableServiceClient) VerifyBatch(ctx context.Context, in *DisposableBatchRequest, opts ...grpc.CallOption) (*DisposableBatchResponse, error) {
+	out := new(DisposableBatchResponse)
+	err := grpc.Invoke(ctx, "/disposable.DisposableService/VerifyBatch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disposableServiceClient) VerifyStream(ctx context.Context, opts ...grpc.CallOption) (DisposableService_VerifyStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_DisposableService_serviceDesc.Streams[0], c.cc, "/disposable.DisposableService/VerifyStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disposableServiceVerifyStreamClient{stream}
+	return x, nil
+}
+
+type DisposableService_VerifyStreamClient interface {
+	Send(*DisposableStreamRequest) error
+	Recv() (*DisposableStreamResponse, error)
+	grpc.ClientStream
+}
+
+type disposableServiceVerifyStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *disposableServiceVerifyStreamClient) Send(m *DisposableStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disposableServiceVerifyStreamClient) Recv() (*DisposableStreamResponse, error) {
+	m := new(DisposableStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disposableServiceClient) CheckDomain(ctx context.Context, in *DisposableDomainRequest, opts ...grpc.CallOption) (*DisposableDomainResponse, error) {
+	out := new(DisposableDomainResponse)
+	err := grpc.Invoke(ctx, "/disposable.DisposableService/CheckDomain", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disposableServiceClient) Normalize(ctx context.Context, in *DisposableNormalizeRequest, opts ...grpc.CallOption) (*DisposableNormalizeResponse, error) {
+	out := new(DisposableNormalizeResponse)
+	err := grpc.Invoke(ctx, "/disposable.DisposableService/Normalize", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for DisposableService service
 
 type DisposableServiceServer interface {
 	Verify(context.Context, *DisposableRequest) (*DisposableResponse, error)
+	VerifyBatch(context.Context, *DisposableBatchRequest) (*DisposableBatchResponse, error)
+	VerifyStream(DisposableService_VerifyStreamServer) error
+	CheckDomain(context.Context, *DisposableDomainRequest) (*DisposableDomainResponse, error)
+	Normalize(context.Context, *DisposableNormalizeRequest) (*DisposableNormalizeResponse, error)
 }
 
 func RegisterDisposableServiceServer(s *grpc.Server, srv DisposableServiceServer) {
@@ -109,6 +367,86 @@ func _DisposableService_Verify_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DisposableService_VerifyBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisposableBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisposableServiceServer).VerifyBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disposable.DisposableService/VerifyBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisposableServiceServer).VerifyBatch(ctx, req.(*DisposableBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisposableService_VerifyStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisposableServiceServer).VerifyStream(&disposableServiceVerifyStreamServer{stream})
+}
+
+type DisposableService_VerifyStreamServer interface {
+	Send(*DisposableStreamResponse) error
+	Recv() (*DisposableStreamRequest, error)
+	grpc.ServerStream
+}
+
+type disposableServiceVerifyStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *disposableServiceVerifyStreamServer) Send(m *DisposableStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disposableServiceVerifyStreamServer) Recv() (*DisposableStreamRequest, error) {
+	m := new(DisposableStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DisposableService_CheckDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisposableDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisposableServiceServer).CheckDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disposable.DisposableService/CheckDomain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisposableServiceServer).CheckDomain(ctx, req.(*DisposableDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DisposableService_Normalize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisposableNormalizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisposableServiceServer).Normalize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/disposable.DisposableService/Normalize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisposableServiceServer).Normalize(ctx, req.(*DisposableNormalizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DisposableService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "disposable.DisposableService",
 	HandlerType: (*DisposableServiceServer)(nil),
@@ -117,28 +455,86 @@ var _DisposableService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Verify",
 			Handler:    _DisposableService_Verify_Handler,
 		},
+		{
+			MethodName: "VerifyBatch",
+			Handler:    _DisposableService_VerifyBatch_Handler,
+		},
+		{
+			MethodName: "CheckDomain",
+			Handler:    _DisposableService_CheckDomain_Handler,
+		},
+		{
+			MethodName: "Normalize",
+			Handler:    _DisposableService_Normalize_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "VerifyStream",
+			Handler:       _DisposableService_VerifyStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/service.proto",
 }
 
 func init() { proto.RegisterFile("protos/service.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xe2, 0x12, 0x29, 0x28, 0xca, 0x2f,
-	0xc9, 0x2f, 0xd6, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x03, 0x73, 0x85, 0xb8, 0x52,
-	0x32, 0x8b, 0x0b, 0xf2, 0x8b, 0x13, 0x93, 0x72, 0x52, 0xa5, 0x84, 0xa1, 0x2a, 0x52, 0x8b, 0x8a,
-	0xf2, 0x8b, 0x8a, 0x21, 0x0a, 0x94, 0x34, 0xb9, 0x04, 0x5d, 0xe0, 0x4a, 0x82, 0x52, 0x0b, 0x4b,
-	0x53, 0x8b, 0x4b, 0x84, 0x44, 0xb8, 0x58, 0x53, 0x73, 0x13, 0x33, 0x73, 0x24, 0x18, 0x15, 0x18,
-	0x35, 0x38, 0x83, 0x20, 0x1c, 0xa5, 0x12, 0x2e, 0x21, 0x64, 0xa5, 0x40, 0x56, 0x5e, 0x71, 0xaa,
-	0x90, 0x18, 0x17, 0x5b, 0x71, 0x49, 0x62, 0x49, 0x69, 0x31, 0x58, 0x31, 0x47, 0x10, 0x94, 0x27,
-	0x24, 0xcb, 0xc5, 0x55, 0x04, 0x31, 0x2e, 0x3e, 0x33, 0x45, 0x82, 0x09, 0x6c, 0x10, 0x27, 0x54,
-	0xc4, 0x33, 0x45, 0x48, 0x1d, 0x68, 0x05, 0xc8, 0x1d, 0x12, 0xcc, 0x40, 0x19, 0x6e, 0x23, 0x41,
-	0x3d, 0x84, 0x43, 0xf5, 0x5c, 0x41, 0x12, 0x41, 0x10, 0x79, 0xa3, 0x38, 0x64, 0x07, 0x06, 0x43,
-	0x3c, 0x27, 0xe4, 0xc9, 0xc5, 0x16, 0x96, 0x5a, 0x94, 0x99, 0x56, 0x29, 0x24, 0x8b, 0xac, 0x11,
-	0xc3, 0x27, 0x52, 0x72, 0xb8, 0xa4, 0x21, 0xae, 0x57, 0x62, 0x70, 0x32, 0xe5, 0x92, 0x32, 0xa8,
-	0x30, 0xb4, 0xd4, 0x4b, 0xcf, 0x2c, 0xc9, 0x28, 0x4d, 0xd2, 0x4b, 0xce, 0xcf, 0x45, 0xd2, 0x12,
-	0x85, 0x14, 0x7e, 0x8b, 0x98, 0xb8, 0x10, 0x06, 0x24, 0xb1, 0x81, 0x83, 0xcf, 0x18, 0x10, 0x00,
-	0x00, 0xff, 0xff, 0xe8, 0xac, 0xa8, 0x9a, 0x77, 0x01, 0x00, 0x00,
+	// 857 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xbc, 0x56, 0xcb, 0x92, 0xdb, 0x44,
+	0x14, 0x45, 0xb2, 0x2d, 0xdb, 0x57, 0xce, 0x50, 0xd3, 0x84, 0xa1, 0x4b, 0x21, 0x94, 0x47, 0x04,
+	0xe2, 0x0d, 0x66, 0x32, 0x29, 0x28, 0x58, 0x51, 0x95, 0xc7, 0x22, 0x0b, 0x58, 0x28, 0x55, 0x29,
+	0xc8, 0x02, 0x57, 0x5b, 0xea, 0x64, 0xba, 0x90, 0xd4, 0xa6, 0x5b, 0x32, 0x09, 0x2b, 0xd6, 0x7c,
+	0x06, 0xbf, 0xc0, 0x6f, 0xb0, 0xe5, 0x0b, 0xf8, 0x11, 0xaa, 0x1f, 0x7a, 0xd8, 0x92, 0x3d, 0x1b,
+	0x98, 0x5d, 0xdf, 0xdb, 0xe7, 0x9e, 0xbe, 0x6f, 0x09, 0x6e, 0x6f, 0x04, 0x2f, 0xb8, 0xfc, 0x5c,
+	0x52, 0xb1, 0x65, 0x31, 0x5d, 0x6a, 0x11, 0x41, 0xc2, 0xe4, 0x86, 0x4b, 0xb2, 0x4e, 0x69, 0xf0,
+	0x9e, 0x45, 0x50, 0x21, 0xb8, 0x90, 0x06, 0x10, 0x6e, 0xe1, 0xf4, 0x49, 0x0d, 0x89, 0xe8, 0xcf,
+	0x25, 0x95, 0x05, 0xba, 0x0d, 0x23, 0x9a, 0x11, 0x96, 0x62, 0x67, 0xee, 0x2c, 0xa6, 0x91, 0x11,
+	0x10, 0x86, 0x31, 0x7d, 0xb3, 0x49, 0x09, 0xcb, 0xb1, 0x3b, 0x77, 0x16, 0x93, 0xa8, 0x12, 0xd1,
+	0x39, 0xcc, 0x52, 0x26, 0x8b, 0xd5, 0x96, 0x0a, 0xc9, 0x78, 0x8e, 0x07, 0xda, 0xcc, 0x57, 0xba,
+	0x17, 0x46, 0x85, 0x4e, 0xc0, 0x25, 0x05, 0x1e, 0xea, 0x0b, 0x97, 0x14, 0xe1, 0xef, 0x43, 0x40,
+	0xed, 0x87, 0xe5, 0x86, 0xe7, 0x92, 0xa2, 0x33, 0xf0, 0x64, 0x41, 0x8a, 0x52, 0xea, 0xa7, 0x27,
+	0x91, 0x95, 0xd0, 0x5d, 0x00, 0x61, 0x9c, 0x5b, 0xb1, 0x44, 0x3f, 0x3f, 0x8d, 0xa6, 0x56, 0xf3,
+	0x2c, 0x41, 0xf7, 0x61, 0xa4, 0xa3, 0xd2, 0x2f, 0xfb, 0x97, 0xa7, 0xcb, 0x26, 0xec, 0xe5, 0x53,
+	0x75, 0x11, 0x99, 0x7b, 0xf4, 0x09, 0x9c, 0x64, 0xa4, 0x88, 0xaf, 0x68, 0xb2, 0x4a, 0x78, 0xa6,
+	0x42, 0x31, 0x2e, 0xdd, 0xb2, 0xda, 0x27, 0x5a, 0xa9, 0xdc, 0xb0, 0xd7, 0x23, 0x7d, 0x6d, 0x25,
+	0xf4, 0x19, 0x20, 0x41, 0x5f, 0x33, 0x59, 0x08, 0x45, 0x5d, 0x51, 0x78, 0x1a, 0x73, 0xda, 0xba,
+	0xb1, 0x34, 0x8f, 0xc1, 0xd7, 0x29, 0xca, 0x49, 0xa1, 0xd2, 0x32, 0xd6, 0xce, 0x9d, 0xb7, 0x9d,
+	0x6b, 0x52, 0xf0, 0xb4, 0x01, 0x46, 0x6d, 0x2b, 0x95, 0x76, 0xc9, 0x4b, 0x11, 0x53, 0x89, 0x27,
+	0xf3, 0xc1, 0x62, 0x1a, 0x55, 0x22, 0x0a, 0x60, 0xc2, 0xb7, 0x54, 0x08, 0x96, 0x50, 0x3c, 0xd5,
+	0xe9, 0xaa, 0x65, 0x15, 0x28, 0x49, 0x53, 0xfe, 0x4b, 0x13, 0x28, 0x98, 0x40, 0xad, 0xd6, 0x7a,
+	0xb8, 0x5f, 0x39, 0xbf, 0x5b, 0xb9, 0x73, 0x98, 0x55, 0x29, 0x13, 0x65, 0x4a, 0xf1, 0xcc, 0x40,
+	0xac, 0x2e, 0x2a, 0x53, 0x8a, 0xe6, 0x30, 0x4b, 0x58, 0xb2, 0x7a, 0xcb, 0xcb, 0x55, 0x46, 0x49,
+	0x8e, 0x6f, 0x69, 0x08, 0x24, 0x2c, 0xf9, 0x81, 0x97, 0xdf, 0x52, 0x92, 0xa3, 0x0f, 0x61, 0x1a,
+	0x93, 0x9c, 0xe7, 0x2c, 0x26, 0x29, 0x3e, 0x31, 0xe5, 0xab, 0x15, 0x21, 0x87, 0xf7, 0x7b, 0x13,
+	0xd1, 0x71, 0xcf, 0xe9, 0xba, 0xf7, 0x10, 0xbc, 0xf8, 0x8a, 0xc6, 0x3f, 0x49, 0xec, 0xce, 0x07,
+	0x0b, 0xff, 0xf2, 0x4e, 0x7f, 0x7a, 0x1f, 0x2b, 0x4c, 0x64, 0xa1, 0xe1, 0x5f, 0x0e, 0xbc, 0xbb,
+	0x77, 0x87, 0x10, 0x0c, 0x73, 0x92, 0x51, 0xfb, 0x86, 0x3e, 0xab, 0x3e, 0xd8, 0x10, 0x29, 0x69,
+	0x62, 0x3b, 0xde, 0x4a, 0x0a, 0xab, 0x73, 0x61, 0x1a, 0x5d, 0x9f, 0x95, 0x4e, 0xf9, 0x65, 0x1b,
+	0x4a, 0x9f, 0x7b, 0xda, 0x6d, 0x74, 0xa0, 0xdd, 0x4c, 0x4d, 0x6d, 0x2b, 0x59, 0xc9, 0x50, 0xe6,
+	0x54, 0x37, 0xce, 0x28, 0xd2, 0x67, 0xa5, 0x7b, 0xc5, 0x52, 0x8a, 0x27, 0xe6, 0x19, 0x75, 0x0e,
+	0x2f, 0xe0, 0xac, 0x89, 0xe6, 0x91, 0xa2, 0xae, 0x26, 0xf9, 0x0c, 0x3c, 0x3d, 0xbc, 0x6a, 0x9e,
+	0x54, 0xef, 0x58, 0x29, 0xfc, 0xd3, 0x81, 0x0f, 0x3a, 0x26, 0x37, 0x34, 0x83, 0x5f, 0xc1, 0x58,
+	0x50, 0x59, 0xa6, 0x85, 0xc4, 0x43, 0x5d, 0xb2, 0x8f, 0xfa, 0x4b, 0x56, 0x39, 0x14, 0x55, 0xf0,
+	0xf0, 0x9b, 0xb6, 0xd3, 0xcf, 0x0b, 0x41, 0x49, 0x56, 0x05, 0x7a, 0x02, 0x2e, 0x4b, 0x6c, 0xed,
+	0x5c, 0x96, 0x34, 0x2b, 0xcc, 0x6d, 0xad, 0xb0, 0x70, 0x0d, 0xb8, 0x4b, 0x60, 0xc3, 0xde, 0x67,
+	0xf8, 0x12, 0x3c, 0xf3, 0xae, 0xa6, 0xb8, 0xde, 0x4b, 0x8b, 0x0e, 0x1f, 0xb4, 0x9d, 0x34, 0x05,
+	0x6e, 0x55, 0xc3, 0xb6, 0x81, 0xd3, 0x5e, 0x2b, 0xe1, 0x6f, 0x03, 0xc0, 0x5d, 0x9b, 0x1b, 0x2a,
+	0xc7, 0x19, 0x78, 0xaa, 0x57, 0x69, 0xa2, 0x3b, 0x77, 0x12, 0x59, 0xe9, 0xbf, 0xda, 0x81, 0xdd,
+	0x11, 0x18, 0xf7, 0x8d, 0xc0, 0xff, 0xbd, 0xe5, 0x76, 0x56, 0x98, 0xdf, 0x59, 0x61, 0xe1, 0x25,
+	0x04, 0x4d, 0x05, 0xbe, 0xe3, 0x22, 0x23, 0x29, 0xfb, 0xf5, 0xf8, 0x07, 0x31, 0xfc, 0xc7, 0x81,
+	0x3b, 0xbd, 0x46, 0x37, 0x54, 0xb9, 0xda, 0xab, 0x61, 0xfb, 0x33, 0xbd, 0xb3, 0x6a, 0x47, 0x7b,
+	0xab, 0x56, 0x65, 0x73, 0x23, 0xf8, 0x96, 0x25, 0x54, 0xd8, 0x9a, 0xd5, 0xb2, 0xe2, 0x53, 0xe9,
+	0x91, 0x78, 0xac, 0x2b, 0x60, 0x84, 0xcb, 0xbf, 0x07, 0xed, 0x5f, 0x84, 0xe7, 0xe6, 0xf7, 0x02,
+	0x3d, 0x03, 0xef, 0x05, 0x15, 0xec, 0xd5, 0x5b, 0x74, 0xf7, 0xd0, 0x5c, 0xe8, 0x78, 0x82, 0x6b,
+	0xc6, 0x26, 0x7c, 0x07, 0x7d, 0x0f, 0xbe, 0xa1, 0xd2, 0x6b, 0x08, 0x85, 0xfd, 0x06, 0xed, 0xb5,
+	0x16, 0x7c, 0x7c, 0x14, 0x53, 0x33, 0xaf, 0x60, 0x66, 0x98, 0xcd, 0xa8, 0xa3, 0x03, 0x66, 0x3b,
+	0x9b, 0x24, 0xb8, 0x77, 0x1c, 0x54, 0x91, 0x2f, 0x9c, 0x0b, 0x07, 0xbd, 0x04, 0x5f, 0x7f, 0x3c,
+	0x6c, 0x9f, 0x1d, 0xe0, 0xdf, 0x59, 0x02, 0xc1, 0xbd, 0xe3, 0xa0, 0xda, 0xf9, 0x1f, 0x61, 0x5a,
+	0xb7, 0x14, 0xfa, 0xb4, 0xdf, 0x68, 0xbf, 0x51, 0x83, 0xfb, 0xd7, 0xe2, 0x2a, 0xfe, 0x47, 0x5f,
+	0x40, 0x70, 0xf1, 0xe6, 0xc1, 0xd7, 0xcb, 0xd7, 0xac, 0xb8, 0x2a, 0xd7, 0xcb, 0x98, 0x67, 0x2d,
+	0xdb, 0x97, 0xad, 0x1f, 0xc7, 0x3f, 0x5c, 0x68, 0x98, 0xd6, 0x9e, 0xfe, 0x6f, 0x7c, 0xf8, 0xef,
+	0x00, 0xa4, 0x2b, 0x7a, 0x4e, 0x70, 0x0a, 0x00, 0x00,
 }
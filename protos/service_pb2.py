@@ -20,7 +20,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='protos/service.proto',
   package='disposable',
   syntax='proto3',
-  serialized_pb=_b('\n\x14protos/service.proto\x12\ndisposable\x1a\x13protos/errors.proto\"\"\n\x11\x44isposableRequest\x12\r\n\x05\x65mail\x18\x01 \x01(\t\"Z\n\x12\x44isposableResponse\x12\x0e\n\x06status\x18\x01 \x01(\x08\x12\x12\n\nrequest_id\x18\x02 \x01(\t\x12 \n\x05\x65rror\x18\x03 \x01(\x0b\x32\x11.disposable.Error2^\n\x11\x44isposableService\x12I\n\x06Verify\x12\x1d.disposable.DisposableRequest\x1a\x1e.disposable.DisposableResponse\"\x00\x42\x35\n\x1a\x30x19.github.com.disposableZ\ndisposable\xa2\x02\nDisposableb\x06proto3')
+  serialized_pb=_b('\n\x14protos/service.proto\x12\ndisposable\x1a\x13protos/errors.proto\"U\n\x11\x44isposableRequest\x12\r\n\x05\x65mail\x18\x01 \x01(\t\x12\x0f\n\x07\x65xplain\x18\x02 \x01(\x08\x12\x14\n\x0clist_version\x18\x03 \x01(\t\x12\n\n\x02\x61t\x18\x04 \x01(\t\"\xe6\x02\n\x12\x44isposableResponse\x12\x0e\n\x06status\x18\x01 \x01(\x08\x12\x12\n\nrequest_id\x18\x02 \x01(\t\x12 \n\x05\x65rror\x18\x03 \x01(\x0b\x32\x11.disposable.Error\x12\x16\n\x0ematched_domain\x18\x04 \x01(\t\x12\x0e\n\x06\x64omain\x18\x05 \x01(\t\x12\x1a\n\x12registrable_domain\x18\x06 \x01(\t\x12\x36\n\x0b\x65xplanation\x18\x07 \x01(\x0b\x32!.disposable.DisposableExplanation\x12\x0f\n\x07sources\x18\x08 \x03(\t\x12\x10\n\x08override\x18\t \x01(\x08\x12\x16\n\x0e\x61llowed_domain\x18\n \x01(\t\x12\x14\n\x0clist_version\x18\x0b \x01(\t\x12\x14\n\x0cmatched_rule\x18\x0c \x01(\t\x12\x14\n\x0c\x64id_you_mean\x18\r \x01(\t\x12\x11\n\tcanonical\x18\x0e \x01(\t\"Z\n\x15\x44isposableExplanation\x12\x14\n\x0clist_version\x18\x01 \x01(\t\x12+\n\x06\x63hecks\x18\x02 \x03(\x0b\x32\x1b.disposable.DisposableCheck\"\x8f\x01\n\x0f\x44isposableCheck\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x0e\n\x06passed\x18\x02 \x01(\x08\x12\x0c\n\x04rule\x18\x03 \x01(\t\x12\x0c\n\x04list\x18\x04 \x01(\t\x12\x16\n\x0ematched_domain\x18\x05 \x01(\t\x12\x0e\n\x06source\x18\x06 \x01(\t\x12\x0c\n\x04line\x18\x07 \x01(\x05\x12\x0c\n\x04\x66ile\x18\x08 \x01(\t\"(\n\x16\x44isposableBatchRequest\x12\x0e\n\x06\x65mails\x18\x01 \x03(\t\"\x90\x01\n\x17\x44isposableBatchResponse\x12\x0e\n\x06status\x18\x01 \x01(\x08\x12\x12\n\nrequest_id\x18\x02 \x01(\t\x12 \n\x05\x65rror\x18\x03 \x01(\x0b\x32\x11.disposable.Error\x12/\n\x07results\x18\x04 \x03(\x0b\x32\x1e.disposable.DisposableResponse\"4\n\x17\x44isposableStreamRequest\x12\n\n\x02id\x18\x01 \x01(\t\x12\r\n\x05\x65mail\x18\x02 \x01(\t\"V\n\x18\x44isposableStreamResponse\x12\n\n\x02id\x18\x01 \x01(\t\x12.\n\x06result\x18\x02 \x01(\x0b\x32\x1e.disposable.DisposableResponse\")\n\x17\x44isposableDomainRequest\x12\x0e\n\x06\x64omain\x18\x01 \x01(\t\"\x85\x02\n\x18\x44isposableDomainResponse\x12\x0e\n\x06status\x18\x01 \x01(\x08\x12\x12\n\nrequest_id\x18\x02 \x01(\t\x12 \n\x05\x65rror\x18\x03 \x01(\x0b\x32\x11.disposable.Error\x12\x0e\n\x06listed\x18\x04 \x01(\x08\x12\x0e\n\x06\x64omain\x18\x05 \x01(\t\x12\x1a\n\x12registrable_domain\x18\x06 \x01(\t\x12\x16\n\x0ematched_domain\x18\x07 \x01(\t\x12\x0f\n\x07sources\x18\x08 \x03(\t\x12\x10\n\x08override\x18\t \x01(\x08\x12\x16\n\x0e\x61llowed_domain\x18\n \x01(\t\x12\x14\n\x0cmatched_rule\x18\x0b \x01(\t\"+\n\x1a\x44isposableNormalizeRequest\x12\r\n\x05\x65mail\x18\x01 \x01(\t\"\xa6\x01\n\x1b\x44isposableNormalizeResponse\x12\x0e\n\x06status\x18\x01 \x01(\x08\x12\x12\n\nrequest_id\x18\x02 \x01(\t\x12 \n\x05\x65rror\x18\x03 \x01(\x0b\x32\x11.disposable.Error\x12\r\n\x05\x65mail\x18\x04 \x01(\t\x12\x11\n\tcanonical\x18\x05 \x01(\t\x12\x10\n\x08provider\x18\x06 \x01(\t\x12\r\n\x05rules\x18\x07 \x03(\t2\xd5\x03\n\x11\x44isposableService\x12I\n\x06Verify\x12\x1d.disposable.DisposableRequest\x1a\x1e.disposable.DisposableResponse\"\x00\x12X\n\x0bVerifyBatch\x12\".disposable.DisposableBatchRequest\x1a#.disposable.DisposableBatchResponse\"\x00\x12_\n\x0cVerifyStream\x12#.disposable.DisposableStreamRequest\x1a$.disposable.DisposableStreamResponse\"\x00(\x01\x30\x01\x12Z\n\x0b\x43heckDomain\x12#.disposable.DisposableDomainRequest\x1a$.disposable.DisposableDomainResponse\"\x00\x12^\n\tNormalize\x12&.disposable.DisposableNormalizeRequest\x1a\'.disposable.DisposableNormalizeResponse\"\x00\x42\x35\n\x1a\x30x19.github.com.disposableZ\ndisposable\xa2\x02\nDisposableb\x06proto3')
   ,
   dependencies=[protos_dot_errors__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -42,6 +42,388 @@ _DISPOSABLEREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='explain', full_name='disposable.DisposableRequest.explain', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='list_version', full_name='disposable.DisposableRequest.list_version', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='at', full_name='disposable.DisposableRequest.at', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=57,
+  serialized_end=142,
+)
+
+
+_DISPOSABLERESPONSE = _descriptor.Descriptor(
+  name='DisposableResponse',
+  full_name='disposable.DisposableResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='status', full_name='disposable.DisposableResponse.status', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='request_id', full_name='disposable.DisposableResponse.request_id', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='disposable.DisposableResponse.error', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='matched_domain', full_name='disposable.DisposableResponse.matched_domain', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='domain', full_name='disposable.DisposableResponse.domain', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='registrable_domain', full_name='disposable.DisposableResponse.registrable_domain', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='explanation', full_name='disposable.DisposableResponse.explanation', index=6,
+      number=7, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sources', full_name='disposable.DisposableResponse.sources', index=7,
+      number=8, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='override', full_name='disposable.DisposableResponse.override', index=8,
+      number=9, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='allowed_domain', full_name='disposable.DisposableResponse.allowed_domain', index=9,
+      number=10, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='list_version', full_name='disposable.DisposableResponse.list_version', index=10,
+      number=11, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='matched_rule', full_name='disposable.DisposableResponse.matched_rule', index=11,
+      number=12, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='did_you_mean', full_name='disposable.DisposableResponse.did_you_mean', index=12,
+      number=13, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='canonical', full_name='disposable.DisposableResponse.canonical', index=13,
+      number=14, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=145,
+  serialized_end=503,
+)
+
+
+_DISPOSABLEEXPLANATION = _descriptor.Descriptor(
+  name='DisposableExplanation',
+  full_name='disposable.DisposableExplanation',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='list_version', full_name='disposable.DisposableExplanation.list_version', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='checks', full_name='disposable.DisposableExplanation.checks', index=1,
+      number=2, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=505,
+  serialized_end=595,
+)
+
+
+_DISPOSABLECHECK = _descriptor.Descriptor(
+  name='DisposableCheck',
+  full_name='disposable.DisposableCheck',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='name', full_name='disposable.DisposableCheck.name', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='passed', full_name='disposable.DisposableCheck.passed', index=1,
+      number=2, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='rule', full_name='disposable.DisposableCheck.rule', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='list', full_name='disposable.DisposableCheck.list', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='matched_domain', full_name='disposable.DisposableCheck.matched_domain', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='source', full_name='disposable.DisposableCheck.source', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='line', full_name='disposable.DisposableCheck.line', index=6,
+      number=7, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='disposable.DisposableCheck.file', index=7,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=598,
+  serialized_end=741,
+)
+
+
+_DISPOSABLEBATCHREQUEST = _descriptor.Descriptor(
+  name='DisposableBatchRequest',
+  full_name='disposable.DisposableBatchRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='emails', full_name='disposable.DisposableBatchRequest.emails', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=743,
+  serialized_end=783,
+)
+
+
+_DISPOSABLEBATCHRESPONSE = _descriptor.Descriptor(
+  name='DisposableBatchResponse',
+  full_name='disposable.DisposableBatchResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='status', full_name='disposable.DisposableBatchResponse.status', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='request_id', full_name='disposable.DisposableBatchResponse.request_id', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='disposable.DisposableBatchResponse.error', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='results', full_name='disposable.DisposableBatchResponse.results', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=786,
+  serialized_end=930,
+)
+
+
+_DISPOSABLESTREAMREQUEST = _descriptor.Descriptor(
+  name='DisposableStreamRequest',
+  full_name='disposable.DisposableStreamRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='disposable.DisposableStreamRequest.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='email', full_name='disposable.DisposableStreamRequest.email', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -54,39 +436,268 @@ _DISPOSABLEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=57,
-  serialized_end=91,
+  serialized_start=932,
+  serialized_end=984,
 )
 
 
-_DISPOSABLERESPONSE = _descriptor.Descriptor(
-  name='DisposableResponse',
-  full_name='disposable.DisposableResponse',
+_DISPOSABLESTREAMRESPONSE = _descriptor.Descriptor(
+  name='DisposableStreamResponse',
+  full_name='disposable.DisposableStreamResponse',
   filename=None,
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
     _descriptor.FieldDescriptor(
-      name='status', full_name='disposable.DisposableResponse.status', index=0,
+      name='id', full_name='disposable.DisposableStreamResponse.id', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='result', full_name='disposable.DisposableStreamResponse.result', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=986,
+  serialized_end=1072,
+)
+
+
+_DISPOSABLEDOMAINREQUEST = _descriptor.Descriptor(
+  name='DisposableDomainRequest',
+  full_name='disposable.DisposableDomainRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='domain', full_name='disposable.DisposableDomainRequest.domain', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1074,
+  serialized_end=1115,
+)
+
+
+_DISPOSABLEDOMAINRESPONSE = _descriptor.Descriptor(
+  name='DisposableDomainResponse',
+  full_name='disposable.DisposableDomainResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='status', full_name='disposable.DisposableDomainResponse.status', index=0,
       number=1, type=8, cpp_type=7, label=1,
       has_default_value=False, default_value=False,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='request_id', full_name='disposable.DisposableResponse.request_id', index=1,
+      name='request_id', full_name='disposable.DisposableDomainResponse.request_id', index=1,
       number=2, type=9, cpp_type=9, label=1,
       has_default_value=False, default_value=_b("").decode('utf-8'),
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
     _descriptor.FieldDescriptor(
-      name='error', full_name='disposable.DisposableResponse.error', index=2,
+      name='error', full_name='disposable.DisposableDomainResponse.error', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='listed', full_name='disposable.DisposableDomainResponse.listed', index=3,
+      number=4, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='domain', full_name='disposable.DisposableDomainResponse.domain', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='registrable_domain', full_name='disposable.DisposableDomainResponse.registrable_domain', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='matched_domain', full_name='disposable.DisposableDomainResponse.matched_domain', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sources', full_name='disposable.DisposableDomainResponse.sources', index=7,
+      number=8, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='override', full_name='disposable.DisposableDomainResponse.override', index=8,
+      number=9, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='allowed_domain', full_name='disposable.DisposableDomainResponse.allowed_domain', index=9,
+      number=10, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='matched_rule', full_name='disposable.DisposableDomainResponse.matched_rule', index=10,
+      number=11, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1118,
+  serialized_end=1379,
+)
+
+
+_DISPOSABLENORMALIZEREQUEST = _descriptor.Descriptor(
+  name='DisposableNormalizeRequest',
+  full_name='disposable.DisposableNormalizeRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='email', full_name='disposable.DisposableNormalizeRequest.email', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1381,
+  serialized_end=1424,
+)
+
+
+_DISPOSABLENORMALIZERESPONSE = _descriptor.Descriptor(
+  name='DisposableNormalizeResponse',
+  full_name='disposable.DisposableNormalizeResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='status', full_name='disposable.DisposableNormalizeResponse.status', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='request_id', full_name='disposable.DisposableNormalizeResponse.request_id', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='disposable.DisposableNormalizeResponse.error', index=2,
       number=3, type=11, cpp_type=10, label=1,
       has_default_value=False, default_value=None,
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='email', full_name='disposable.DisposableNormalizeResponse.email', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='canonical', full_name='disposable.DisposableNormalizeResponse.canonical', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='provider', full_name='disposable.DisposableNormalizeResponse.provider', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='rules', full_name='disposable.DisposableNormalizeResponse.rules', index=6,
+      number=7, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -99,13 +710,30 @@ _DISPOSABLERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=93,
-  serialized_end=183,
+  serialized_start=1427,
+  serialized_end=1593,
 )
 
 _DISPOSABLERESPONSE.fields_by_name['error'].message_type = protos_dot_errors__pb2._ERROR
+_DISPOSABLERESPONSE.fields_by_name['explanation'].message_type = _DISPOSABLEEXPLANATION
+_DISPOSABLEEXPLANATION.fields_by_name['checks'].message_type = _DISPOSABLECHECK
+_DISPOSABLEBATCHRESPONSE.fields_by_name['error'].message_type = protos_dot_errors__pb2._ERROR
+_DISPOSABLEBATCHRESPONSE.fields_by_name['results'].message_type = _DISPOSABLERESPONSE
+_DISPOSABLESTREAMRESPONSE.fields_by_name['result'].message_type = _DISPOSABLERESPONSE
+_DISPOSABLEDOMAINRESPONSE.fields_by_name['error'].message_type = protos_dot_errors__pb2._ERROR
+_DISPOSABLENORMALIZERESPONSE.fields_by_name['error'].message_type = protos_dot_errors__pb2._ERROR
 DESCRIPTOR.message_types_by_name['DisposableRequest'] = _DISPOSABLEREQUEST
 DESCRIPTOR.message_types_by_name['DisposableResponse'] = _DISPOSABLERESPONSE
+DESCRIPTOR.message_types_by_name['DisposableExplanation'] = _DISPOSABLEEXPLANATION
+DESCRIPTOR.message_types_by_name['DisposableCheck'] = _DISPOSABLECHECK
+DESCRIPTOR.message_types_by_name['DisposableBatchRequest'] = _DISPOSABLEBATCHREQUEST
+DESCRIPTOR.message_types_by_name['DisposableBatchResponse'] = _DISPOSABLEBATCHRESPONSE
+DESCRIPTOR.message_types_by_name['DisposableStreamRequest'] = _DISPOSABLESTREAMREQUEST
+DESCRIPTOR.message_types_by_name['DisposableStreamResponse'] = _DISPOSABLESTREAMRESPONSE
+DESCRIPTOR.message_types_by_name['DisposableDomainRequest'] = _DISPOSABLEDOMAINREQUEST
+DESCRIPTOR.message_types_by_name['DisposableDomainResponse'] = _DISPOSABLEDOMAINRESPONSE
+DESCRIPTOR.message_types_by_name['DisposableNormalizeRequest'] = _DISPOSABLENORMALIZEREQUEST
+DESCRIPTOR.message_types_by_name['DisposableNormalizeResponse'] = _DISPOSABLENORMALIZERESPONSE
 
 DisposableRequest = _reflection.GeneratedProtocolMessageType('DisposableRequest', (_message.Message,), dict(
   DESCRIPTOR = _DISPOSABLEREQUEST,
@@ -121,6 +749,76 @@ DisposableResponse = _reflection.GeneratedProtocolMessageType('DisposableRespons
   ))
 _sym_db.RegisterMessage(DisposableResponse)
 
+DisposableExplanation = _reflection.GeneratedProtocolMessageType('DisposableExplanation', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLEEXPLANATION,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableExplanation)
+  ))
+_sym_db.RegisterMessage(DisposableExplanation)
+
+DisposableCheck = _reflection.GeneratedProtocolMessageType('DisposableCheck', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLECHECK,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableCheck)
+  ))
+_sym_db.RegisterMessage(DisposableCheck)
+
+DisposableBatchRequest = _reflection.GeneratedProtocolMessageType('DisposableBatchRequest', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLEBATCHREQUEST,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableBatchRequest)
+  ))
+_sym_db.RegisterMessage(DisposableBatchRequest)
+
+DisposableBatchResponse = _reflection.GeneratedProtocolMessageType('DisposableBatchResponse', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLEBATCHRESPONSE,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableBatchResponse)
+  ))
+_sym_db.RegisterMessage(DisposableBatchResponse)
+
+DisposableStreamRequest = _reflection.GeneratedProtocolMessageType('DisposableStreamRequest', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLESTREAMREQUEST,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableStreamRequest)
+  ))
+_sym_db.RegisterMessage(DisposableStreamRequest)
+
+DisposableStreamResponse = _reflection.GeneratedProtocolMessageType('DisposableStreamResponse', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLESTREAMRESPONSE,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableStreamResponse)
+  ))
+_sym_db.RegisterMessage(DisposableStreamResponse)
+
+DisposableDomainRequest = _reflection.GeneratedProtocolMessageType('DisposableDomainRequest', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLEDOMAINREQUEST,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableDomainRequest)
+  ))
+_sym_db.RegisterMessage(DisposableDomainRequest)
+
+DisposableDomainResponse = _reflection.GeneratedProtocolMessageType('DisposableDomainResponse', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLEDOMAINRESPONSE,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableDomainResponse)
+  ))
+_sym_db.RegisterMessage(DisposableDomainResponse)
+
+DisposableNormalizeRequest = _reflection.GeneratedProtocolMessageType('DisposableNormalizeRequest', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLENORMALIZEREQUEST,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableNormalizeRequest)
+  ))
+_sym_db.RegisterMessage(DisposableNormalizeRequest)
+
+DisposableNormalizeResponse = _reflection.GeneratedProtocolMessageType('DisposableNormalizeResponse', (_message.Message,), dict(
+  DESCRIPTOR = _DISPOSABLENORMALIZERESPONSE,
+  __module__ = 'protos.service_pb2'
+  # @@protoc_insertion_point(class_scope:disposable.DisposableNormalizeResponse)
+  ))
+_sym_db.RegisterMessage(DisposableNormalizeResponse)
+
 
 DESCRIPTOR.has_options = True
 DESCRIPTOR._options = _descriptor._ParseOptions(descriptor_pb2.FileOptions(), _b('\n\0320x19.github.com.disposableZ\ndisposable\242\002\nDisposable'))
@@ -144,6 +842,26 @@ class DisposableServiceStub(object):
         request_serializer=DisposableRequest.SerializeToString,
         response_deserializer=DisposableResponse.FromString,
         )
+    self.VerifyBatch = channel.unary_unary(
+        '/disposable.DisposableService/VerifyBatch',
+        request_serializer=DisposableBatchRequest.SerializeToString,
+        response_deserializer=DisposableBatchResponse.FromString,
+        )
+    self.VerifyStream = channel.stream_stream(
+        '/disposable.DisposableService/VerifyStream',
+        request_serializer=DisposableStreamRequest.SerializeToString,
+        response_deserializer=DisposableStreamResponse.FromString,
+        )
+    self.CheckDomain = channel.unary_unary(
+        '/disposable.DisposableService/CheckDomain',
+        request_serializer=DisposableDomainRequest.SerializeToString,
+        response_deserializer=DisposableDomainResponse.FromString,
+        )
+    self.Normalize = channel.unary_unary(
+        '/disposable.DisposableService/Normalize',
+        request_serializer=DisposableNormalizeRequest.SerializeToString,
+        response_deserializer=DisposableNormalizeResponse.FromString,
+        )
 
 
 class DisposableServiceServicer(object):
@@ -153,6 +871,26 @@ class DisposableServiceServicer(object):
     context.set_details('Method not implemented!')
     raise NotImplementedError('Method not implemented!')
 
+  def VerifyBatch(self, request, context):
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def VerifyStream(self, request_iterator, context):
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def CheckDomain(self, request, context):
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
+  def Normalize(self, request, context):
+    context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+    context.set_details('Method not implemented!')
+    raise NotImplementedError('Method not implemented!')
+
 
 def add_DisposableServiceServicer_to_server(servicer, server):
   rpc_method_handlers = {
@@ -161,6 +899,26 @@ def add_DisposableServiceServicer_to_server(servicer, server):
           request_deserializer=DisposableRequest.FromString,
           response_serializer=DisposableResponse.SerializeToString,
       ),
+      'VerifyBatch': grpc.unary_unary_rpc_method_handler(
+          servicer.VerifyBatch,
+          request_deserializer=DisposableBatchRequest.FromString,
+          response_serializer=DisposableBatchResponse.SerializeToString,
+      ),
+      'VerifyStream': grpc.stream_stream_rpc_method_handler(
+          servicer.VerifyStream,
+          request_deserializer=DisposableStreamRequest.FromString,
+          response_serializer=DisposableStreamResponse.SerializeToString,
+      ),
+      'CheckDomain': grpc.unary_unary_rpc_method_handler(
+          servicer.CheckDomain,
+          request_deserializer=DisposableDomainRequest.FromString,
+          response_serializer=DisposableDomainResponse.SerializeToString,
+      ),
+      'Normalize': grpc.unary_unary_rpc_method_handler(
+          servicer.Normalize,
+          request_deserializer=DisposableNormalizeRequest.FromString,
+          response_serializer=DisposableNormalizeResponse.SerializeToString,
+      ),
   }
   generic_handler = grpc.method_handlers_generic_handler(
       'disposable.DisposableService', rpc_method_handlers)
@@ -170,23 +928,54 @@ def add_DisposableServiceServicer_to_server(servicer, server):
 class BetaDisposableServiceServicer(object):
   def Verify(self, request, context):
     context.code(beta_interfaces.StatusCode.UNIMPLEMENTED)
+  def VerifyBatch(self, request, context):
+    context.code(beta_interfaces.StatusCode.UNIMPLEMENTED)
+  def VerifyStream(self, request_iterator, context):
+    context.code(beta_interfaces.StatusCode.UNIMPLEMENTED)
+  def CheckDomain(self, request, context):
+    context.code(beta_interfaces.StatusCode.UNIMPLEMENTED)
+  def Normalize(self, request, context):
+    context.code(beta_interfaces.StatusCode.UNIMPLEMENTED)
 
 
 class BetaDisposableServiceStub(object):
   def Verify(self, request, timeout, metadata=None, with_call=False, protocol_options=None):
     raise NotImplementedError()
   Verify.future = None
+  def VerifyBatch(self, request, timeout, metadata=None, with_call=False, protocol_options=None):
+    raise NotImplementedError()
+  VerifyBatch.future = None
+  def VerifyStream(self, request_iterator, timeout, metadata=None, protocol_options=None):
+    raise NotImplementedError()
+  def CheckDomain(self, request, timeout, metadata=None, with_call=False, protocol_options=None):
+    raise NotImplementedError()
+  CheckDomain.future = None
+  def Normalize(self, request, timeout, metadata=None, with_call=False, protocol_options=None):
+    raise NotImplementedError()
+  Normalize.future = None
 
 
 def beta_create_DisposableService_server(servicer, pool=None, pool_size=None, default_timeout=None, maximum_timeout=None):
   request_deserializers = {
+    ('disposable.DisposableService', 'CheckDomain'): DisposableDomainRequest.FromString,
+    ('disposable.DisposableService', 'Normalize'): DisposableNormalizeRequest.FromString,
     ('disposable.DisposableService', 'Verify'): DisposableRequest.FromString,
+    ('disposable.DisposableService', 'VerifyBatch'): DisposableBatchRequest.FromString,
+    ('disposable.DisposableService', 'VerifyStream'): DisposableStreamRequest.FromString,
   }
   response_serializers = {
+    ('disposable.DisposableService', 'CheckDomain'): DisposableDomainResponse.SerializeToString,
+    ('disposable.DisposableService', 'Normalize'): DisposableNormalizeResponse.SerializeToString,
     ('disposable.DisposableService', 'Verify'): DisposableResponse.SerializeToString,
+    ('disposable.DisposableService', 'VerifyBatch'): DisposableBatchResponse.SerializeToString,
+    ('disposable.DisposableService', 'VerifyStream'): DisposableStreamResponse.SerializeToString,
   }
   method_implementations = {
+    ('disposable.DisposableService', 'CheckDomain'): face_utilities.unary_unary_inline(servicer.CheckDomain),
+    ('disposable.DisposableService', 'Normalize'): face_utilities.unary_unary_inline(servicer.Normalize),
     ('disposable.DisposableService', 'Verify'): face_utilities.unary_unary_inline(servicer.Verify),
+    ('disposable.DisposableService', 'VerifyBatch'): face_utilities.unary_unary_inline(servicer.VerifyBatch),
+    ('disposable.DisposableService', 'VerifyStream'): face_utilities.stream_stream_inline(servicer.VerifyStream),
   }
   server_options = beta_implementations.server_options(request_deserializers=request_deserializers, response_serializers=response_serializers, thread_pool=pool, thread_pool_size=pool_size, default_timeout=default_timeout, maximum_timeout=maximum_timeout)
   return beta_implementations.server(method_implementations, options=server_options)
@@ -194,13 +983,25 @@ def beta_create_DisposableService_server(servicer, pool=None, pool_size=None, de
 
 def beta_create_DisposableService_stub(channel, host=None, metadata_transformer=None, pool=None, pool_size=None):
   request_serializers = {
+    ('disposable.DisposableService', 'CheckDomain'): DisposableDomainRequest.SerializeToString,
+    ('disposable.DisposableService', 'Normalize'): DisposableNormalizeRequest.SerializeToString,
     ('disposable.DisposableService', 'Verify'): DisposableRequest.SerializeToString,
+    ('disposable.DisposableService', 'VerifyBatch'): DisposableBatchRequest.SerializeToString,
+    ('disposable.DisposableService', 'VerifyStream'): DisposableStreamRequest.SerializeToString,
   }
   response_deserializers = {
+    ('disposable.DisposableService', 'CheckDomain'): DisposableDomainResponse.FromString,
+    ('disposable.DisposableService', 'Normalize'): DisposableNormalizeResponse.FromString,
     ('disposable.DisposableService', 'Verify'): DisposableResponse.FromString,
+    ('disposable.DisposableService', 'VerifyBatch'): DisposableBatchResponse.FromString,
+    ('disposable.DisposableService', 'VerifyStream'): DisposableStreamResponse.FromString,
   }
   cardinalities = {
+    'CheckDomain': cardinality.Cardinality.UNARY_UNARY,
+    'Normalize': cardinality.Cardinality.UNARY_UNARY,
     'Verify': cardinality.Cardinality.UNARY_UNARY,
+    'VerifyBatch': cardinality.Cardinality.UNARY_UNARY,
+    'VerifyStream': cardinality.Cardinality.STREAM_STREAM,
   }
   stub_options = beta_implementations.stub_options(host=host, metadata_transformer=metadata_transformer, request_serializers=request_serializers, response_deserializers=response_deserializers, thread_pool=pool, thread_pool_size=pool_size)
   return beta_implementations.dynamic_stub(channel, 'disposable.DisposableService', cardinalities, options=stub_options)
@@ -0,0 +1,140 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	suffixRuleNormal uint8 = 1 << iota
+	suffixRuleWildcard
+	suffixRuleException
+)
+
+// PublicSuffixList - Parsed Public Suffix List (https://publicsuffix.org) used
+// to figure out registrable domain (eTLD+1) of an address.
+type PublicSuffixList struct {
+	// rules maps rule without its "*." or "!" prefix to rule kinds
+	rules map[string]uint8
+}
+
+// PublicSuffix - Will return public suffix of provided domain following the
+// PSL algorithm. Nil list (or no matching rule) falls back to default "*"
+// rule meaning last label of the domain is the public suffix.
+func (psl *PublicSuffixList) PublicSuffix(domain string) string {
+	domain = NormalizeDomain(domain)
+	labels := strings.Split(domain, ".")
+	n := len(labels)
+	best := 1
+
+	for i := 0; psl != nil && i < n; i++ {
+		kind := psl.rules[strings.Join(labels[i:], ".")]
+
+		if kind&suffixRuleException != 0 {
+			return strings.Join(labels[i+1:], ".")
+		}
+
+		if kind&suffixRuleNormal != 0 && n-i > best {
+			best = n - i
+		}
+
+		if kind&suffixRuleWildcard != 0 && i > 0 && n-i+1 > best {
+			best = n - i + 1
+		}
+	}
+
+	return strings.Join(labels[n-best:], ".")
+}
+
+// IsPublicSuffix - Will check if provided domain is public suffix on its own
+func (psl *PublicSuffixList) IsPublicSuffix(domain string) bool {
+	return psl.PublicSuffix(domain) == NormalizeDomain(domain)
+}
+
+// Known - Will check if list carries a rule for provided suffix itself rather
+// than it being a public suffix by the default "*" rule only. Nil list knows
+// no suffixes.
+func (psl *PublicSuffixList) Known(suffix string) bool {
+	if psl == nil {
+		return false
+	}
+
+	return psl.rules[NormalizeDomain(suffix)]&(suffixRuleNormal|suffixRuleWildcard) != 0
+}
+
+// RegistrableDomain - Will return registrable domain (public suffix plus one
+// label) of provided domain. Returns empty string if domain is a public
+// suffix itself.
+func (psl *PublicSuffixList) RegistrableDomain(domain string) string {
+	domain = NormalizeDomain(domain)
+	suffix := psl.PublicSuffix(domain)
+
+	if suffix == domain {
+		return ""
+	}
+
+	registrable := domain[:len(domain)-len(suffix)-1]
+	if dot := strings.LastIndexByte(registrable, '.'); dot >= 0 {
+		registrable = registrable[dot+1:]
+	}
+
+	return registrable + "." + suffix
+}
+
+// Len - Will return number of rules in the list
+func (psl *PublicSuffixList) Len() int {
+	if psl == nil {
+		return 0
+	}
+
+	return len(psl.rules)
+}
+
+// ParsePublicSuffixList - Will parse list in the official PSL format. Only the
+// first whitespace delimited token of each line is taken into account and
+// lines starting with // are comments.
+func ParsePublicSuffixList(r io.Reader) (*PublicSuffixList, error) {
+	psl := &PublicSuffixList{rules: map[string]uint8{}}
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		rule := NormalizeDomain(strings.Fields(line)[0])
+
+		switch {
+		case strings.HasPrefix(rule, "!"):
+			psl.rules[rule[1:]] |= suffixRuleException
+		case strings.HasPrefix(rule, "*."):
+			psl.rules[rule[2:]] |= suffixRuleWildcard
+		default:
+			psl.rules[rule] |= suffixRuleNormal
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return psl, nil
+}
+
+// LoadPublicSuffixList - Will load and parse public suffix list from file
+func LoadPublicSuffixList(source string) (*PublicSuffixList, error) {
+	f, err := os.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParsePublicSuffixList(f)
+}
@@ -0,0 +1,70 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const testPublicSuffixList = `
+// ===BEGIN ICANN DOMAINS===
+com
+uk
+co.uk
+jp
+*.kawasaki.jp
+!city.kawasaki.jp
+
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+s3.amazonaws.com Amazon S3
+`
+
+func TestPublicSuffixList(t *testing.T) {
+	psl, err := ParsePublicSuffixList(strings.NewReader(testPublicSuffixList))
+
+	Convey("Public suffix list parsed successfully", t, func() {
+		So(err, ShouldBeNil)
+		So(psl.Len(), ShouldEqual, 8)
+	})
+
+	Convey("Registrable domain follows the longest matching rule", t, func() {
+		So(psl.RegistrableDomain("mx1.mailinator.com"), ShouldEqual, "mailinator.com")
+		So(psl.RegistrableDomain("Mailinator.COM."), ShouldEqual, "mailinator.com")
+		So(psl.RegistrableDomain("a.b.example.co.uk"), ShouldEqual, "example.co.uk")
+		So(psl.RegistrableDomain("burner.github.io"), ShouldEqual, "burner.github.io")
+		So(psl.RegistrableDomain("bucket.s3.amazonaws.com"), ShouldEqual, "bucket.s3.amazonaws.com")
+		So(psl.RegistrableDomain("foo.bar.kawasaki.jp"), ShouldEqual, "foo.bar.kawasaki.jp")
+		So(psl.RegistrableDomain("www.city.kawasaki.jp"), ShouldEqual, "city.kawasaki.jp")
+		So(psl.RegistrableDomain("example.unknowntld"), ShouldEqual, "example.unknowntld")
+	})
+
+	Convey("Public suffixes have no registrable domain", t, func() {
+		So(psl.RegistrableDomain("co.uk"), ShouldEqual, "")
+		So(psl.RegistrableDomain("github.io"), ShouldEqual, "")
+		So(psl.RegistrableDomain("bar.kawasaki.jp"), ShouldEqual, "")
+		So(psl.IsPublicSuffix("co.uk"), ShouldBeTrue)
+		So(psl.IsPublicSuffix("example.co.uk"), ShouldBeFalse)
+	})
+
+	Convey("Only suffixes carrying a rule are known", t, func() {
+		So(psl.Known("CO.UK"), ShouldBeTrue)
+		So(psl.Known("kawasaki.jp"), ShouldBeTrue)
+		So(psl.Known("city.kawasaki.jp"), ShouldBeFalse)
+		So(psl.Known("unknowntld"), ShouldBeFalse)
+
+		var missing *PublicSuffixList
+		So(missing.Known("com"), ShouldBeFalse)
+	})
+
+	Convey("Missing list falls back to the default rule", t, func() {
+		var missing *PublicSuffixList
+		So(missing.RegistrableDomain("a.b.example.co.uk"), ShouldEqual, "co.uk")
+		So(missing.RegistrableDomain("com"), ShouldEqual, "")
+	})
+}
@@ -0,0 +1,315 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"golang.org/x/net/context"
+)
+
+// RemoteFetchTimeout - Default timeout of a single remote source fetch
+const RemoteFetchTimeout = 30 * time.Second
+
+// DefaultMaxFetchSize - Default limit of remote list and signature bodies,
+// in bytes. Larger bodies fail the fetch.
+const DefaultMaxFetchSize = 64 << 20
+
+// RemoteStatus - Outcome of the latest fetches of a remote list source
+type RemoteStatus struct {
+	Name        string     `json:"name"`
+	URL         string     `json:"url"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// remoteList - Last good copy of a remote source along with validators used
+// to fetch it conditionally next time.
+type remoteList struct {
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+	data         []byte
+	lastSuccess  time.Time
+	lastError    error
+	lastErrorAt  time.Time
+}
+
+// remoteFetch - Body of a remote list along with validators it was served
+// with. Nothing of it is kept until it is verified, parsed and committed.
+type remoteFetch struct {
+	data         []byte
+	etag         string
+	lastModified string
+
+	// fresh is set when body came from the server rather than from last
+	// good copy served while the server is failing
+	fresh bool
+}
+
+// fetch - Will fetch remote list unless it did not change since last fetch.
+// On failure last good copy is returned along with the error, if there is
+// one.
+func (rl *remoteList) fetch(client *http.Client, url string, maxSize int64) (*remoteFetch, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	metricRemoteFetches.Add(1)
+
+	fetched, err := rl.get(client, url, maxSize)
+	if err != nil {
+		metricRemoteFetchErrors.Add(1)
+		rl.lastError = err
+		rl.lastErrorAt = time.Now()
+
+		if rl.data == nil {
+			return nil, err
+		}
+
+		return &remoteFetch{data: rl.data, etag: rl.etag, lastModified: rl.lastModified}, err
+	}
+
+	return fetched, nil
+}
+
+// get - Will issue conditional GET for provided url
+func (rl *remoteList) get(client *http.Client, url string, maxSize int64) (*remoteFetch, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if rl.data != nil {
+		if rl.etag != "" {
+			req.Header.Set("If-None-Match", rl.etag)
+		}
+
+		if rl.lastModified != "" {
+			req.Header.Set("If-Modified-Since", rl.lastModified)
+		}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && rl.data != nil:
+		metricRemoteNotModified.Add(1)
+		return &remoteFetch{data: rl.data, etag: rl.etag, lastModified: rl.lastModified, fresh: true}, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected (status: %s) fetching (url: %s)", resp.Status, url)
+	}
+
+	data, err := readBody(resp.Body, maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not read (url: %s) due to (err: %s)", url, err)
+	}
+
+	return &remoteFetch{
+		data:         data,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+		fresh:        true,
+	}, nil
+}
+
+// commit - Will keep provided fetch as the last good copy once it has been
+// verified and parsed. Last good copies served while the server is failing
+// are not committed again. Errors of previous fetches are cleared.
+func (rl *remoteList) commit(fetched *remoteFetch) {
+	if !fetched.fresh {
+		return
+	}
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.data = fetched.data
+	rl.etag = fetched.etag
+	rl.lastModified = fetched.lastModified
+	rl.lastSuccess = time.Now()
+	rl.lastError = nil
+	rl.lastErrorAt = time.Time{}
+}
+
+// reject - Will record why fetched list could not be used. Last good copy is
+// kept as it is.
+func (rl *remoteList) reject(err error) error {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.lastError = err
+	rl.lastErrorAt = time.Now()
+	return err
+}
+
+// succeededAt - Will return time of the last successful fetch
+func (rl *remoteList) succeededAt() time.Time {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	return rl.lastSuccess
+}
+
+// status - Will return outcome of the latest fetches
+func (rl *remoteList) status(source ListSource) RemoteStatus {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	status := RemoteStatus{Name: source.Name, URL: source.Path}
+
+	if !rl.lastSuccess.IsZero() {
+		lastSuccess := rl.lastSuccess
+		status.LastSuccess = &lastSuccess
+	}
+
+	if rl.lastError != nil {
+		lastErrorAt := rl.lastErrorAt
+		status.LastError = rl.lastError.Error()
+		status.LastErrorAt = &lastErrorAt
+	}
+
+	return status
+}
+
+// fetchRemote - Will fetch provided remote source falling back to its last
+// good copy when fetch fails.
+func (de *DisposableEmails) fetchRemote(source ListSource) (*remoteFetch, error) {
+	fetched, err := de.remote(source).fetch(de.client(), source.Path, de.maxFetchSize())
+	if err != nil && fetched != nil {
+		log.Warnf("[fetch_remote] Failed to fetch (source: %s) - (url: %s) due to (err: %s). Falling back to last good copy...", source.Name, source.Path, err)
+		return fetched, nil
+	}
+
+	return fetched, err
+}
+
+// fetchSignature - Will fetch detached signature of remote list. Signatures
+// are small so they are always fetched in full, within the same size limit.
+func (de *DisposableEmails) fetchSignature(url string) ([]byte, error) {
+	resp, err := de.client().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected (status: %s) fetching (url: %s)", resp.Status, url)
+	}
+
+	data, err := readBody(resp.Body, de.maxFetchSize())
+	if err != nil {
+		return nil, fmt.Errorf("could not read (url: %s) due to (err: %s)", url, err)
+	}
+
+	return data, nil
+}
+
+// readBody - Will read provided body failing when it is larger than maxSize
+// bytes rather than buffering all of it
+func readBody(body io.Reader, maxSize int64) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("body exceeds (max_size: %d bytes)", maxSize)
+	}
+
+	return data, nil
+}
+
+// maxFetchSize - Will return limit of remote bodies, DefaultMaxFetchSize
+// unless MaxFetchSize is set
+func (de *DisposableEmails) maxFetchSize() int64 {
+	if de.MaxFetchSize <= 0 {
+		return DefaultMaxFetchSize
+	}
+
+	return de.MaxFetchSize
+}
+
+// client - Will return client remote sources are fetched with
+func (de *DisposableEmails) client() *http.Client {
+	if de.HTTPClient == nil {
+		return &http.Client{Timeout: RemoteFetchTimeout}
+	}
+
+	return de.HTTPClient
+}
+
+// remote - Will return fetch state of provided remote source
+func (de *DisposableEmails) remote(source ListSource) *remoteList {
+	de.remotesMu.Lock()
+	defer de.remotesMu.Unlock()
+
+	if de.remotes == nil {
+		de.remotes = map[string]*remoteList{}
+	}
+
+	if _, ok := de.remotes[source.Path]; !ok {
+		de.remotes[source.Path] = &remoteList{}
+	}
+
+	return de.remotes[source.Path]
+}
+
+// RemoteSources - Will return configured remote sources, allowlist ones
+// included.
+func (de *DisposableEmails) RemoteSources() []ListSource {
+	sources := []ListSource{}
+
+	for _, source := range append(append([]ListSource{}, de.Sources...), de.AllowSources...) {
+		if source.IsRemote() {
+			sources = append(sources, source)
+		}
+	}
+
+	return sources
+}
+
+// RemoteStatuses - Will return outcome of the latest fetches of every remote
+// source.
+func (de *DisposableEmails) RemoteStatuses() []RemoteStatus {
+	statuses := []RemoteStatus{}
+
+	for _, source := range de.RemoteSources() {
+		statuses = append(statuses, de.remote(source).status(source))
+	}
+
+	return statuses
+}
+
+// Refresh - Will reload list every interval so that remote sources get
+// refetched. Unchanged remote lists are not downloaded again. Blocks until
+// context is done.
+func (de *DisposableEmails) Refresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			log.Infof("[refresh] Refreshing disposable emails from (remote_sources: %d)...", len(de.RemoteSources()))
+
+			if err := de.Load(); err != nil {
+				log.Errorf("[refresh] Failed to refresh list due to (err: %s). Previous list keeps serving.", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRemoteSource(t *testing.T) {
+	Convey("Remote list is fetched conditionally and last good copy survives failures", t, func() {
+		var requests, notModified, failing int32
+		body := "mailinator.com\nguerrillamail.com\n"
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&requests, 1)
+
+			if atomic.LoadInt32(&failing) == 1 {
+				http.Error(w, "upstream down", http.StatusBadGateway)
+				return
+			}
+
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.Header().Set("ETag", `"v1"`)
+			w.Write([]byte(body))
+		}))
+		defer server.Close()
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "upstream", Path: server.URL + "/emails.txt"}}}
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 2)
+		So(emails.Provenance("mailinator.com"), ShouldResemble, []string{"upstream"})
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 2)
+		So(atomic.LoadInt32(&notModified), ShouldEqual, 1)
+
+		statuses := emails.RemoteStatuses()
+		So(len(statuses), ShouldEqual, 1)
+		So(statuses[0].Name, ShouldEqual, "upstream")
+		So(statuses[0].LastSuccess, ShouldNotBeNil)
+		So(statuses[0].LastError, ShouldBeEmpty)
+
+		atomic.StoreInt32(&failing, 1)
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 2)
+		So(emails.DomainExists("guerrillamail.com"), ShouldBeTrue)
+
+		statuses = emails.RemoteStatuses()
+		So(statuses[0].LastError, ShouldContainSubstring, "502")
+		So(statuses[0].LastErrorAt, ShouldNotBeNil)
+		So(statuses[0].LastSuccess, ShouldNotBeNil)
+		So(atomic.LoadInt32(&requests), ShouldEqual, 3)
+	})
+
+	Convey("List goes stale while remote source keeps failing and recovers with it", t, func() {
+		var failing int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.LoadInt32(&failing) == 1 {
+				http.Error(w, "upstream down", http.StatusBadGateway)
+				return
+			}
+
+			w.Write([]byte("mailinator.com\n"))
+		}))
+		defer server.Close()
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "upstream", Path: server.URL + "/emails.txt"}}, MaxAge: 20 * time.Millisecond}
+		So(emails.Load(), ShouldBeNil)
+		fetchedAt := emails.FetchedAt()
+
+		atomic.StoreInt32(&failing, 1)
+		time.Sleep(30 * time.Millisecond)
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.FetchedAt(), ShouldEqual, fetchedAt)
+		So(emails.LoadedAt(), ShouldHappenAfter, fetchedAt)
+		So(emails.Stale(), ShouldBeTrue)
+		So(emails.RemoteStatuses()[0].LastError, ShouldNotBeEmpty)
+
+		atomic.StoreInt32(&failing, 0)
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Stale(), ShouldBeFalse)
+		So(emails.RemoteStatuses()[0].LastError, ShouldBeEmpty)
+		So(emails.RemoteStatuses()[0].LastErrorAt, ShouldBeNil)
+	})
+
+	Convey("Remote list that was never fetched fails the load", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "upstream", Path: server.URL + "/emails.txt"}}}
+
+		So(emails.Load(), ShouldNotBeNil)
+		So(emails.Loaded(), ShouldBeFalse)
+		So(emails.RemoteStatuses()[0].LastSuccess, ShouldBeNil)
+	})
+	Convey("Remote list larger than size limit fails the load", t, func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("mailinator.com\nguerrillamail.com\n"))
+		}))
+		defer server.Close()
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "upstream", Path: server.URL + "/emails.txt"}}, MaxFetchSize: 16}
+
+		err := emails.Load()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "max_size: 16")
+		So(emails.Loaded(), ShouldBeFalse)
+
+		emails.MaxFetchSize = 64
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 2)
+	})
+}
@@ -5,11 +5,13 @@
 package main
 
 import (
+	"expvar"
 	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
 	"syscall"
 	"time"
 
@@ -24,19 +26,65 @@ import (
 	"github.com/0x19/disposable/protos"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// DisposableServiceName - Fully qualified GRPC service name as reported by
+// health service.
+const DisposableServiceName = "disposable.DisposableService"
+
+// DefaultMaxBatchSize - Default maximum number of email addresses accepted by
+// VerifyBatch
+const DefaultMaxBatchSize = 1000
+
 // Service -
 type Service struct {
 	GRPCAddr, HTTPAddr string
 
+	// MaxBatchSize - Maximum number of email addresses accepted by VerifyBatch.
+	// Zero or less means DefaultMaxBatchSize.
+	MaxBatchSize int
+
+	// StreamConcurrency - Number of addresses VerifyStream verifies at once
+	StreamConcurrency int
+
+	// SMTPUTF8 - Accept UTF-8 local parts (RFC 6531) on verified addresses
+	SMTPUTF8 bool
+
+	// SingleLabelDomains - Accept verified addresses of single label domains
+	// such as localhost
+	SingleLabelDomains bool
+
+	// Confusables - Lookalikes of protected domains are rejected as well as
+	// domains mixing scripts. Nil disables the check, it is enabled with
+	// VERIFY_CONFUSABLES.
+	Confusables *Confusables
+
+	// Suggester - Suggests popular provider domain mistyped domains were
+	// most likely meant to be. Nil disables suggestions.
+	Suggester *Suggester
+
+	// StrictSuggestions - Reject addresses there is a confident suggestion
+	// for instead of only returning it
+	StrictSuggestions bool
+
+	// ProviderRules - Rules canonical mailboxes of addresses are derived by
+	ProviderRules *ProviderRules
+
 	done             chan bool
 	GRPCListener     net.Listener
+	HTTPListener     net.Listener
 	GRPC             *grpc.Server
+	Health           *health.Server
 	Cache            *cache.MemoryTTL
 	Ctx              context.Context
 	CtxCancel        context.CancelFunc
 	DisposableEmails *DisposableEmails
+
+	// grpcListening and httpListening are 1 while listeners are serving
+	grpcListening int32
+	httpListening int32
 }
 
 // RegisterAndListenGrpcServer -
@@ -74,10 +122,40 @@ func (s *Service) RegisterAndListenGrpcServer() error {
 
 	s.GRPC = grpc.NewServer([]grpc.ServerOption{grpc.Creds(creds)}...)
 	disposable.RegisterDisposableServiceServer(s.GRPC, s)
+	healthpb.RegisterHealthServer(s.GRPC, s.Health)
+
+	s.setListening(&s.grpcListening, true)
+	defer s.setListening(&s.grpcListening, false)
 
 	return s.GRPC.Serve(s.GRPCListener)
 }
 
+// RegisterAdminRoutes - Will register snapshot admin routes protected by admin
+// credentials. Nothing is registered unless admin credentials are set.
+func (s *Service) RegisterAdminRoutes(httpmux *mux.Router) bool {
+	if username, password := AdminCredentials(); username == "" || password == "" {
+		return false
+	}
+
+	httpmux.HandleFunc("/v1/admin/snapshots", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleListSnapshots(s, w, req)
+	}, AdminAuth, CapturePanic, CompressionHandler)).Methods("GET")
+
+	httpmux.HandleFunc("/v1/admin/snapshots/{from}/diff/{to}", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleDiffSnapshots(s, w, req)
+	}, AdminAuth, CapturePanic, CompressionHandler)).Methods("GET")
+
+	httpmux.HandleFunc("/v1/admin/snapshots/{version}/rollback", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleRollbackSnapshot(s, w, req)
+	}, AdminAuth, CapturePanic)).Methods("POST")
+
+	httpmux.HandleFunc("/v1/admin/rollback", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleReleaseRollback(s, w, req)
+	}, AdminAuth, CapturePanic)).Methods("DELETE")
+
+	return true
+}
+
 // RegisterAndListenHTTPServer -
 func (s *Service) RegisterAndListenHTTPServer() error {
 	log.Infof("[register_http_server] Registering HTTP services...")
@@ -89,6 +167,32 @@ func (s *Service) RegisterAndListenHTTPServer() error {
 		HandleVerifyEmail(s, w, req)
 	}, BasicAuth, CapturePanic, CompressionHandler)).Methods("POST")
 
+	httpmux.HandleFunc("/v1/verify/batch", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleVerifyBatch(s, w, req)
+	}, BasicAuth, CapturePanic, CompressionHandler)).Methods("POST")
+
+	httpmux.HandleFunc("/v1/domains/{domain}", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleCheckDomain(s, w, req)
+	}, BasicAuth, CapturePanic, CompressionHandler)).Methods("GET")
+
+	httpmux.HandleFunc("/v1/normalize", Use(func(w http.ResponseWriter, req *http.Request) {
+		HandleNormalize(s, w, req)
+	}, BasicAuth, CapturePanic, CompressionHandler)).Methods("POST")
+
+	if !s.RegisterAdminRoutes(httpmux) {
+		log.Warnf("[register_http_server] Admin routes are disabled as ADMIN_USERNAME and ADMIN_PASSWORD are not set")
+	}
+
+	httpmux.HandleFunc("/healthz", func(w http.ResponseWriter, req *http.Request) {
+		HandleHealthz(s, w, req)
+	}).Methods("GET")
+
+	httpmux.HandleFunc("/readyz", func(w http.ResponseWriter, req *http.Request) {
+		HandleReadyz(s, w, req)
+	}).Methods("GET")
+
+	httpmux.Handle("/debug/vars", Use(expvar.Handler().ServeHTTP, BasicAuth)).Methods("GET")
+
 	srv := &graceful.Server{
 		Timeout: 10 * time.Second,
 		Server: &http.Server{
@@ -97,7 +201,29 @@ func (s *Service) RegisterAndListenHTTPServer() error {
 		},
 	}
 
-	return srv.ListenAndServe()
+	var err error
+
+	if s.HTTPListener, err = net.Listen("tcp", s.HTTPAddr); err != nil {
+		return err
+	}
+
+	s.setListening(&s.httpListening, true)
+	defer s.setListening(&s.httpListening, false)
+
+	return srv.Serve(s.HTTPListener)
+}
+
+// SetServingStatus - Will set health status of both the server as a whole and
+// the disposable service.
+func (s *Service) SetServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	s.Health.SetServingStatus("", status)
+	s.Health.SetServingStatus(DisposableServiceName, status)
+}
+
+// AddressOptions - Will return syntax options verified addresses are parsed
+// with
+func (s *Service) AddressOptions() AddressOptions {
+	return AddressOptions{SMTPUTF8: s.SMTPUTF8, SingleLabelDomains: s.SingleLabelDomains}
 }
 
 // HandleSigterm - Will basically wait for channel to close and than initiate
@@ -107,6 +233,23 @@ func (s *Service) HandleSigterm(kill chan os.Signal) {
 	s.CtxCancel()
 }
 
+// HandleSighup - Will reload disposable emails list every time SIGHUP is
+// received. Previous list keeps serving if reload fails.
+func (s *Service) HandleSighup(hup chan os.Signal) {
+	for {
+		select {
+		case <-hup:
+			log.Infof("[sighup] Received SIGHUP. Reloading disposable emails...")
+
+			if err := s.DisposableEmails.Load(); err != nil {
+				log.Errorf("[sighup] Failed to reload disposable emails due to (err: %s). Previous list keeps serving.", err)
+			}
+		case <-s.Ctx.Done():
+			return
+		}
+	}
+}
+
 // Start -
 func (s *Service) Start() (err error) {
 	log.Infof("[start] Starting up (grpc: %s) and (http: %s) services...", s.GRPCAddr, s.HTTPAddr)
@@ -114,22 +257,45 @@ func (s *Service) Start() (err error) {
 
 	errors := make(chan error)
 	kill := make(chan os.Signal, 1)
+	hup := make(chan os.Signal, 1)
 	s.done = make(chan bool)
 
 	signal.Notify(kill, os.Interrupt)
 	signal.Notify(kill, syscall.SIGTERM)
+	signal.Notify(hup, syscall.SIGHUP)
 
 	go s.HandleSigterm(kill)
+	go s.HandleSighup(hup)
 
 	go func() { errors <- s.RegisterAndListenGrpcServer() }()
 	go func() { errors <- s.RegisterAndListenHTTPServer() }()
 
+	// Verification traffic is refused and health reports NOT_SERVING until
+	// disposable emails are loaded for the first time.
 	go func() {
 		if err := s.DisposableEmails.Load(); err != nil {
 			errors <- err
+			return
 		}
+
+		log.Infof("[start] Disposable emails loaded. Refreshing service health...")
+		s.UpdateHealth()
 	}()
 
+	go s.MonitorHealth(OptionDuration("HEALTH_CHECK_INTERVAL", 5*time.Second))
+
+	if len(s.DisposableEmails.RemoteSources()) > 0 {
+		go s.DisposableEmails.Refresh(s.Ctx, OptionDuration("DISPOSABLE_EMAILS_REFRESH_INTERVAL", 1*time.Hour))
+	}
+
+	if OptionBool("DISPOSABLE_EMAILS_WATCH", true) {
+		go func() {
+			if err := s.DisposableEmails.Watch(s.Ctx); err != nil {
+				log.Errorf("[start] Could not watch disposable emails for changes due to (err: %s)", err)
+			}
+		}()
+	}
+
 	select {
 	case err := <-errors:
 		log.Errorf("[start] Failed to serve services due to (err: %s)", err)
@@ -154,12 +320,30 @@ func New() (*Service, error) {
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
-	return &Service{
-		GRPCAddr:         OptionString("GRPC_ADDR", ":6874"),
-		HTTPAddr:         OptionString("HTTP_ADDR", ":4432"),
-		Cache:            cache,
-		Ctx:              ctx,
-		CtxCancel:        cancel,
-		DisposableEmails: emails,
-	}, nil
+	s := &Service{
+		GRPCAddr:           OptionString("GRPC_ADDR", ":6874"),
+		HTTPAddr:           OptionString("HTTP_ADDR", ":4432"),
+		MaxBatchSize:       OptionInt("VERIFY_BATCH_MAX_SIZE", DefaultMaxBatchSize),
+		StreamConcurrency:  OptionInt("VERIFY_STREAM_CONCURRENCY", runtime.NumCPU()),
+		SMTPUTF8:           OptionBool("VERIFY_SMTPUTF8", false),
+		SingleLabelDomains: OptionBool("VERIFY_SINGLE_LABEL_DOMAINS", false),
+		StrictSuggestions:  OptionBool("VERIFY_SUGGEST_STRICT", false),
+		Cache:              cache,
+		Ctx:                ctx,
+		CtxCancel:          cancel,
+		Health:             health.NewServer(),
+		DisposableEmails:   emails,
+		ProviderRules:      NewProviderRulesFromEnv(),
+	}
+
+	if OptionBool("VERIFY_CONFUSABLES", false) {
+		s.Confusables = NewConfusables()
+	}
+
+	if OptionBool("VERIFY_SUGGEST", true) {
+		s.Suggester = NewSuggesterFromEnv()
+	}
+
+	s.SetServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return s, nil
 }
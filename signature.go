@@ -0,0 +1,135 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// SignatureExtension - Detached signature of a list file is expected next to
+// it, named after it with this extension appended.
+const SignatureExtension = ".sig"
+
+// ErrSignatureMismatch - Signature is well formed but made by none of the
+// trusted keys or over different contents.
+var ErrSignatureMismatch = errors.New("signature does not match any of the trusted public keys")
+
+// ParsePublicKeys - Will parse comma separated list of base64 encoded ed25519
+// public keys. More than one key allows rotating signing keys.
+func ParsePublicKeys(spec string) ([]ed25519.PublicKey, error) {
+	keys := []ed25519.PublicKey{}
+
+	for _, part := range strings.Split(spec, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+
+		key, err := base64.StdEncoding.DecodeString(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key (key: %s) due to (err: %s)", part, err)
+		}
+
+		if len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid public key (key: %s) of (size: %d)", part, len(key))
+		}
+
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+
+	return keys, nil
+}
+
+// ParseSignature - Will parse detached ed25519 signature stored either raw or
+// base64 encoded.
+func ParseSignature(data []byte) ([]byte, error) {
+	if len(data) == ed25519.SignatureSize {
+		return data, nil
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature due to (err: %s)", err)
+	}
+
+	if len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("invalid signature of (size: %d)", len(sig))
+	}
+
+	return sig, nil
+}
+
+// VerifySignature - Will check that provided signature over data was made by
+// any of the provided keys.
+func VerifySignature(keys []ed25519.PublicKey, data, signature []byte) error {
+	sig, err := ParseSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if ed25519.Verify(key, data, sig) {
+			return nil
+		}
+	}
+
+	return ErrSignatureMismatch
+}
+
+// SignatureURL - Will return URL of detached signature of remote list at
+// provided URL. Signature extension goes onto the path so that query string
+// and fragment are kept as they are.
+func SignatureURL(listURL string) (string, error) {
+	u, err := url.Parse(listURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path += SignatureExtension
+	if u.RawPath != "" {
+		u.RawPath += SignatureExtension
+	}
+
+	return u.String(), nil
+}
+
+// verifyListData - Will verify list file contents against its detached
+// signature. Nothing is verified unless public keys are configured.
+func (de *DisposableEmails) verifyListData(source ListSource, file string, data []byte) error {
+	if len(de.PublicKeys) == 0 {
+		return nil
+	}
+
+	var signature []byte
+	var err error
+
+	if source.IsRemote() {
+		var signatureURL string
+		if signatureURL, err = SignatureURL(file); err == nil {
+			signature, err = de.fetchSignature(signatureURL)
+		}
+	} else {
+		signature, err = ioutil.ReadFile(file + SignatureExtension)
+	}
+
+	if err != nil {
+		metricListSignatureErrors.Add(1)
+		return fmt.Errorf("could not read signature of (file: %s) due to (err: %s)", file, err)
+	}
+
+	if err := VerifySignature(de.PublicKeys, data, signature); err != nil {
+		metricListSignatureErrors.Add(1)
+		return fmt.Errorf("could not verify (file: %s) due to (err: %s)", file, err)
+	}
+
+	return nil
+}
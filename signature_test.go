@@ -0,0 +1,193 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/crypto/ed25519"
+	"google.golang.org/grpc/health"
+)
+
+// signList - Will write list along with its base64 encoded detached signature
+func signList(key ed25519.PrivateKey, file string, data []byte) error {
+	if err := ioutil.WriteFile(file, data, 0644); err != nil {
+		return err
+	}
+
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(key, data))
+	return ioutil.WriteFile(file+SignatureExtension, []byte(sig+"\n"), 0644)
+}
+
+func TestSignatures(t *testing.T) {
+	public, private, _ := ed25519.GenerateKey(rand.Reader)
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+
+	Convey("Public keys are parsed out of comma separated base64 keys", t, func() {
+		keys, err := ParsePublicKeys(" " + base64.StdEncoding.EncodeToString(public) + ",")
+		So(err, ShouldBeNil)
+		So(len(keys), ShouldEqual, 1)
+		So([]byte(keys[0]), ShouldResemble, []byte(public))
+
+		_, err = ParsePublicKeys("bm90IGEga2V5")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Both raw and base64 signatures are verified", t, func() {
+		data := []byte("mailinator.com\n")
+		sig := ed25519.Sign(private, data)
+
+		So(VerifySignature([]ed25519.PublicKey{public}, data, sig), ShouldBeNil)
+		So(VerifySignature([]ed25519.PublicKey{public}, data, []byte(base64.StdEncoding.EncodeToString(sig))), ShouldBeNil)
+		So(VerifySignature([]ed25519.PublicKey{public}, []byte("gmail.com\n"), sig), ShouldEqual, ErrSignatureMismatch)
+		So(VerifySignature([]ed25519.PublicKey{public}, data, []byte("garbage")), ShouldNotBeNil)
+	})
+
+	Convey("List failing verification is rejected and previous list keeps serving", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		source := filepath.Join(dir, "emails.txt")
+		So(signList(private, source, []byte("mailinator.com\n")), ShouldBeNil)
+
+		emails := &DisposableEmails{
+			Sources:    []ListSource{{Name: "burner", Path: source}},
+			PublicKeys: []ed25519.PublicKey{public},
+		}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 1)
+
+		loadErr, _ := emails.LoadError()
+		So(loadErr, ShouldBeEmpty)
+
+		So(signList(other, source, []byte("gmail.com\n")), ShouldBeNil)
+		So(emails.Load(), ShouldNotBeNil)
+		So(emails.DomainExists("mailinator.com"), ShouldBeTrue)
+		So(emails.DomainExists("gmail.com"), ShouldBeFalse)
+
+		service := &Service{Health: health.NewServer(), DisposableEmails: emails}
+		r := service.HealthReport()
+		So(r.ListLoadError, ShouldContainSubstring, ErrSignatureMismatch.Error())
+		So(r.ListLoadErrorAt, ShouldNotBeNil)
+
+		So(os.Remove(source+SignatureExtension), ShouldBeNil)
+		So(emails.Load(), ShouldNotBeNil)
+
+		So(signList(private, source, []byte("guerrillamail.com\n")), ShouldBeNil)
+		So(emails.Load(), ShouldBeNil)
+		So(emails.DomainExists("guerrillamail.com"), ShouldBeTrue)
+
+		loadErr, _ = emails.LoadError()
+		So(loadErr, ShouldBeEmpty)
+	})
+
+	Convey("Signatures are skipped in directory sources and fetched for remote ones", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(signList(private, filepath.Join(dir, "emails.txt"), []byte("mailinator.com\n")), ShouldBeNil)
+
+		server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+		defer server.Close()
+
+		emails := &DisposableEmails{
+			Sources: []ListSource{
+				{Name: "local", Path: dir},
+				{Name: "upstream", Path: server.URL + "/emails.txt"},
+			},
+			PublicKeys: []ed25519.PublicKey{public},
+		}
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 1)
+		So(len(emails.Index().Files()), ShouldEqual, 2)
+		So(emails.Provenance("mailinator.com"), ShouldResemble, []string{"local", "upstream"})
+
+		emails = &DisposableEmails{
+			Sources:    []ListSource{{Name: "upstream", Path: server.URL + "/emails.txt?token=x#list"}},
+			PublicKeys: []ed25519.PublicKey{public},
+		}
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 1)
+	})
+
+	Convey("Signature extension goes onto remote list path", t, func() {
+		for list, signature := range map[string]string{
+			"https://example.com/emails.txt":                "https://example.com/emails.txt.sig",
+			"https://example.com/emails.txt?token=x":        "https://example.com/emails.txt.sig?token=x",
+			"https://example.com/emails.txt?token=x#latest": "https://example.com/emails.txt.sig?token=x#latest",
+			"https://example.com/a%2Fb.txt":                 "https://example.com/a%2Fb.txt.sig",
+		} {
+			signatureURL, err := SignatureURL(list)
+			So(err, ShouldBeNil)
+			So(signatureURL, ShouldEqual, signature)
+		}
+
+		_, err := SignatureURL("https://example.com/%zz")
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Remote list failing verification is never kept as last good copy", t, func() {
+		var mu sync.Mutex
+		var notModified int
+		etag, body, sig := `"v1"`, []byte("gmail.com\n"), ed25519.Sign(private, []byte("mailinator.com\n"))
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if filepath.Ext(r.URL.Path) == SignatureExtension {
+				w.Write([]byte(base64.StdEncoding.EncodeToString(sig)))
+				return
+			}
+
+			if r.Header.Get("If-None-Match") == etag {
+				notModified++
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+
+			w.Header().Set("ETag", etag)
+			w.Write(body)
+		}))
+		defer server.Close()
+
+		emails := &DisposableEmails{
+			Sources:    []ListSource{{Name: "upstream", Path: server.URL + "/emails.txt"}},
+			PublicKeys: []ed25519.PublicKey{public},
+		}
+
+		So(emails.Load().Error(), ShouldContainSubstring, ErrSignatureMismatch.Error())
+		So(emails.Load().Error(), ShouldContainSubstring, ErrSignatureMismatch.Error())
+		So(emails.Loaded(), ShouldBeFalse)
+		So(notModified, ShouldEqual, 0)
+
+		status := emails.RemoteStatuses()[0]
+		So(status.LastSuccess, ShouldBeNil)
+		So(status.LastError, ShouldContainSubstring, ErrSignatureMismatch.Error())
+
+		mu.Lock()
+		etag, body = `"v2"`, []byte("mailinator.com\n")
+		mu.Unlock()
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Load(), ShouldBeNil)
+		So(notModified, ShouldEqual, 1)
+		So(emails.DomainExists("mailinator.com"), ShouldBeTrue)
+		So(emails.DomainExists("gmail.com"), ShouldBeFalse)
+		So(emails.RemoteStatuses()[0].LastSuccess, ShouldNotBeNil)
+	})
+}
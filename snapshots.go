@@ -0,0 +1,310 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// DefaultHistorySize - Number of snapshots kept unless configured otherwise
+const DefaultHistorySize = 10
+
+// Snapshot - Summary of a loaded list kept in history. Snapshots are
+// identified by content hash of the list so reloading unchanged sources does
+// not produce a new one.
+type Snapshot struct {
+	Version        string     `json:"version"`
+	LoadedAt       time.Time  `json:"loaded_at"`
+	Domains        int        `json:"domains"`
+	AllowedDomains int        `json:"allowed_domains"`
+	Files          []ListFile `json:"files"`
+	Serving        bool       `json:"serving"`
+}
+
+// SnapshotList - Snapshots in history, newest first, along with version that
+// is serving and version rolled back to, if any.
+type SnapshotList struct {
+	Serving   string     `json:"serving"`
+	Pinned    string     `json:"pinned,omitempty"`
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// SnapshotDiff - Entries added and removed between two snapshots. Exact-only
+// entries carry their "=" prefix so switching entry between exact and suffix
+// matching shows up as removed and added.
+type SnapshotDiff struct {
+	From         string   `json:"from"`
+	To           string   `json:"to"`
+	Added        []string `json:"added"`
+	Removed      []string `json:"removed"`
+	AllowAdded   []string `json:"allow_added"`
+	AllowRemoved []string `json:"allow_removed"`
+}
+
+// activation - List that started serving at given time
+type activation struct {
+	index *DomainIndex
+	at    time.Time
+}
+
+// record - Will add provided index to history. Index with the same version as
+// the latest snapshot replaces it. Oldest snapshots are dropped once history
+// is full.
+func (de *DisposableEmails) record(index *DomainIndex) {
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	if n := len(de.history); n > 0 && de.history[n-1].Version() == index.Version() {
+		de.history[n-1] = index
+		return
+	}
+
+	de.history = append(de.history, index)
+
+	size := de.HistorySize
+	if size <= 0 {
+		size = DefaultHistorySize
+	}
+
+	if len(de.history) > size {
+		de.history = append([]*DomainIndex{}, de.history[len(de.history)-size:]...)
+		de.forget()
+	}
+}
+
+// activate - Will note that provided index started serving now. Reloads of
+// unchanged list are not noted.
+func (de *DisposableEmails) activate(index *DomainIndex) {
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	if n := len(de.activations); n > 0 && de.activations[n-1].index != nil && de.activations[n-1].index.Version() == index.Version() {
+		return
+	}
+
+	de.activations = append(de.activations, activation{index: index, at: time.Now()})
+}
+
+// forget - Will forget activations of lists dropped out of history. Dropped
+// list that is still serving is kept. Activations of forgotten lists stay in
+// place so time they were serving is not attributed to other lists.
+func (de *DisposableEmails) forget() {
+	kept := map[string]bool{de.Index().Version(): true}
+	for _, index := range de.history {
+		kept[index.Version()] = true
+	}
+
+	for i := range de.activations {
+		if index := de.activations[i].index; index != nil && !kept[index.Version()] {
+			de.activations[i].index = nil
+		}
+	}
+
+	for len(de.activations) > 0 && de.activations[0].index == nil {
+		de.activations = de.activations[1:]
+	}
+}
+
+// ActiveAt - Will return list that was serving at provided time. Lists
+// dropped out of history can not be returned.
+func (de *DisposableEmails) ActiveAt(at time.Time) (*DomainIndex, error) {
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	for i := len(de.activations) - 1; i >= 0; i-- {
+		if de.activations[i].at.After(at) {
+			continue
+		}
+
+		if de.activations[i].index == nil {
+			break
+		}
+
+		return de.activations[i].index, nil
+	}
+
+	return nil, fmt.Errorf("no list serving at (time: %s) is kept in history", at.Format(time.RFC3339))
+}
+
+// IndexFor - Will return list of provided version or list that was serving at
+// provided RFC 3339 time. Version takes precedence. Currently serving list is
+// returned when neither is provided.
+func (de *DisposableEmails) IndexFor(version, at string) (*DomainIndex, error) {
+	if version != "" {
+		return de.snapshot(version)
+	}
+
+	if at == "" {
+		return de.Index(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return de.ActiveAt(t)
+}
+
+// snapshot - Will return index of provided version out of history
+func (de *DisposableEmails) snapshot(version string) (*DomainIndex, error) {
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	for i := len(de.history) - 1; i >= 0; i-- {
+		if de.history[i].Version() == version {
+			return de.history[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("no snapshot of (version: %s) in history", version)
+}
+
+// latest - Will return the most recently loaded index or nil if nothing was
+// loaded yet.
+func (de *DisposableEmails) latest() *DomainIndex {
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	if len(de.history) == 0 {
+		return nil
+	}
+
+	return de.history[len(de.history)-1]
+}
+
+// Snapshots - Will return snapshots in history, newest first
+func (de *DisposableEmails) Snapshots() *SnapshotList {
+	current := de.Index()
+	list := &SnapshotList{Serving: current.Version(), Pinned: de.Pinned(), Snapshots: []Snapshot{}}
+
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	for i := len(de.history) - 1; i >= 0; i-- {
+		index := de.history[i]
+		list.Snapshots = append(list.Snapshots, Snapshot{
+			Version:        index.Version(),
+			LoadedAt:       index.LoadedAt(),
+			Domains:        index.Len(),
+			AllowedDomains: index.Allowlist().Len(),
+			Files:          index.Files(),
+			Serving:        index == current,
+		})
+	}
+
+	return list
+}
+
+// Diff - Will return entries added and removed between two snapshots
+func (de *DisposableEmails) Diff(from, to string) (*SnapshotDiff, error) {
+	fromIndex, err := de.snapshot(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toIndex, err := de.snapshot(to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &SnapshotDiff{From: from, To: to}
+	diff.Added, diff.Removed = diffIndexes(fromIndex, toIndex)
+	diff.AllowAdded, diff.AllowRemoved = diffIndexes(fromIndex.Allowlist(), toIndex.Allowlist())
+	return diff, nil
+}
+
+// diffIndexes - Will return entries of to missing in from and the other way
+// around. Either of indexes may be nil.
+func diffIndexes(from, to *DomainIndex) ([]string, []string) {
+	if from == nil {
+		from = emptyIndex
+	}
+
+	if to == nil {
+		to = emptyIndex
+	}
+
+	return missingEntries(to, from), missingEntries(from, to)
+}
+
+// missingEntries - Will return entries of index a that index b does not have
+func missingEntries(a, b *DomainIndex) []string {
+	missing := []string{}
+
+	for _, domain := range a.list {
+		entry := a.domains[domain]
+		if other, ok := b.domains[domain]; ok && other.exact == entry.exact {
+			continue
+		}
+
+		if entry.exact {
+			domain = ExactEntryPrefix + domain
+		}
+		missing = append(missing, domain)
+	}
+
+	return missing
+}
+
+// Rollback - Will instantly serve snapshot of provided version and pin it.
+// Lists loaded while pinned are kept in history but do not replace it until
+// Release is called.
+func (de *DisposableEmails) Rollback(version string) (*DomainIndex, error) {
+	index, err := de.snapshot(version)
+	if err != nil {
+		return nil, err
+	}
+
+	de.serving.Lock()
+	defer de.serving.Unlock()
+
+	de.historyMu.Lock()
+	de.pinned = index
+	de.historyMu.Unlock()
+
+	old := de.serve(index)
+	metricListRollbacks.Add(1)
+
+	log.Warnf("[rollback] Rolled back from (version: %s) to (version: %s) - (domains: %d). Loaded lists won't be served until released.", old.Version(), index.Version(), index.Len())
+	return index, nil
+}
+
+// Release - Will unpin snapshot rolled back to and serve the most recently
+// loaded list again.
+func (de *DisposableEmails) Release() *DomainIndex {
+	de.serving.Lock()
+	defer de.serving.Unlock()
+
+	de.historyMu.Lock()
+	de.pinned = nil
+	de.historyMu.Unlock()
+
+	index := de.latest()
+	if index == nil {
+		return de.Index()
+	}
+
+	old := de.serve(index)
+
+	log.Infof("[release] Released rollback to (version: %s). Serving latest (version: %s) - (domains: %d)", old.Version(), index.Version(), index.Len())
+	return index
+}
+
+// Pinned - Will return version of snapshot rolled back to or empty string if
+// there was no rollback.
+func (de *DisposableEmails) Pinned() string {
+	de.historyMu.Lock()
+	defer de.historyMu.Unlock()
+
+	if de.pinned == nil {
+		return ""
+	}
+
+	return de.pinned.Version()
+}
@@ -0,0 +1,172 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSnapshots(t *testing.T) {
+	Convey("Every distinct loaded list is kept in bounded history", t, func() {
+		emails := &DisposableEmails{HistorySize: 2}
+
+		emails.Build([]string{"mailinator.com"})
+		first := emails.Index().Version()
+
+		emails.Build([]string{"mailinator.com"})
+		So(len(emails.Snapshots().Snapshots), ShouldEqual, 1)
+
+		emails.Build([]string{"mailinator.com", "guerrillamail.com"})
+		emails.Build([]string{"guerrillamail.com"})
+
+		list := emails.Snapshots()
+		So(len(list.Snapshots), ShouldEqual, 2)
+		So(list.Serving, ShouldEqual, emails.Index().Version())
+		So(list.Snapshots[0].Version, ShouldEqual, emails.Index().Version())
+		So(list.Snapshots[0].Serving, ShouldBeTrue)
+		So(list.Snapshots[1].Serving, ShouldBeFalse)
+		So(list.Snapshots[1].Domains, ShouldEqual, 2)
+
+		_, err := emails.Rollback(first)
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Snapshots are diffed entry by entry", t, func() {
+		emails := &DisposableEmails{}
+
+		emails.Build([]string{"mailinator.com", "guerrillamail.com"})
+		from := emails.Index().Version()
+
+		emails.Build([]string{"=mailinator.com", "wiki.8191.at"})
+		to := emails.Index().Version()
+
+		diff, err := emails.Diff(from, to)
+		So(err, ShouldBeNil)
+		So(diff.Added, ShouldResemble, []string{"=mailinator.com", "wiki.8191.at"})
+		So(diff.Removed, ShouldResemble, []string{"mailinator.com", "guerrillamail.com"})
+		So(diff.AllowAdded, ShouldBeEmpty)
+		So(diff.AllowRemoved, ShouldBeEmpty)
+
+		_, err = emails.Diff(from, "0000000000000000")
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Rolled back snapshot keeps serving across loads until released", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		list := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(list, []byte("mailinator.com\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "burner", Path: list}}}
+		So(emails.Load(), ShouldBeNil)
+		good := emails.Index().Version()
+
+		So(ioutil.WriteFile(list, []byte("mailinator.com\ngmail.com\n"), 0644), ShouldBeNil)
+		So(emails.Load(), ShouldBeNil)
+		So(emails.IsOK("john@gmail.com"), ShouldBeFalse)
+		bad := emails.Index().Version()
+
+		index, err := emails.Rollback(good)
+		So(err, ShouldBeNil)
+		So(index.Version(), ShouldEqual, good)
+		So(emails.Pinned(), ShouldEqual, good)
+		So(emails.IsOK("john@gmail.com"), ShouldBeTrue)
+
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Index().Version(), ShouldEqual, good)
+		So(emails.Snapshots().Snapshots[0].Version, ShouldEqual, bad)
+
+		So(emails.Release().Version(), ShouldEqual, bad)
+		So(emails.Pinned(), ShouldBeEmpty)
+		So(emails.IsOK("john@gmail.com"), ShouldBeFalse)
+	})
+
+	Convey("Lists are looked up by time they were serving at", t, func() {
+		emails := &DisposableEmails{HistorySize: 2}
+
+		_, err := emails.ActiveAt(time.Now())
+		So(err, ShouldNotBeNil)
+
+		times := []time.Time{}
+		for _, domain := range []string{"mailinator.com", "guerrillamail.com", "wiki.8191.at"} {
+			emails.Build([]string{domain})
+			emails.Build([]string{domain})
+			time.Sleep(10 * time.Millisecond)
+			times = append(times, time.Now())
+		}
+
+		So(len(emails.activations), ShouldEqual, 2)
+
+		_, err = emails.ActiveAt(times[0])
+		So(err, ShouldNotBeNil)
+
+		index, err := emails.ActiveAt(times[1])
+		So(err, ShouldBeNil)
+		So(index.All(), ShouldResemble, []string{"guerrillamail.com"})
+
+		index, err = emails.IndexFor("", times[2].Format(time.RFC3339Nano))
+		So(err, ShouldBeNil)
+		So(index.Version(), ShouldEqual, emails.Index().Version())
+
+		index, err = emails.IndexFor("", "")
+		So(index, ShouldEqual, emails.Index())
+	})
+
+	Convey("Snapshot rolled back to keeps serving while lists load concurrently", t, func() {
+		emails := &DisposableEmails{HistorySize: 1000}
+
+		emails.Build([]string{"mailinator.com"})
+		good := emails.Index().Version()
+
+		var wg sync.WaitGroup
+		done := make(chan bool)
+
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+
+			go func(w int) {
+				defer wg.Done()
+
+				for i := 0; ; i++ {
+					select {
+					case <-done:
+						return
+					default:
+						emails.Build([]string{fmt.Sprintf("burner-%d-%d.example.com", w, i%50)})
+					}
+				}
+			}(w)
+		}
+
+		mismatches := 0
+		for i := 0; i < 200; i++ {
+			if _, err := emails.Rollback(good); err != nil {
+				mismatches++
+				continue
+			}
+
+			if emails.Index().Version() != emails.Pinned() {
+				mismatches++
+			}
+
+			emails.Release()
+		}
+
+		close(done)
+		wg.Wait()
+
+		So(mismatches, ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ListSource - Named location disposable emails are loaded from. Path is
+// either a single file, a directory whose files are all loaded, a glob or an
+// HTTP(S) url.
+type ListSource struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// IsRemote - Will check if source is fetched over HTTP(S)
+func (ls ListSource) IsRemote() bool {
+	path := strings.ToLower(ls.Path)
+	return strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://")
+}
+
+// IsGlob - Will check if source path is a glob pattern
+func (ls ListSource) IsGlob() bool {
+	return !ls.IsRemote() && strings.ContainsAny(ls.Path, "*?[")
+}
+
+// Files - Will resolve source into files it is made of, sorted by name.
+// Hidden files, signatures and subdirectories of directory sources are
+// skipped. Glob that matches nothing is an error. Remote sources are made of
+// their url.
+func (ls ListSource) Files() ([]string, error) {
+	if ls.IsRemote() {
+		return []string{ls.Path}, nil
+	}
+
+	if ls.IsGlob() {
+		matches, err := filepath.Glob(ls.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		files := []string{}
+		for _, match := range matches {
+			if fi, err := os.Stat(match); err == nil && fi.Mode().IsRegular() && !isSignature(match) {
+				files = append(files, match)
+			}
+		}
+
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no files match (source: %s) pattern (path: %s)", ls.Name, ls.Path)
+		}
+
+		return files, nil
+	}
+
+	fi, err := os.Stat(ls.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !fi.IsDir() {
+		return []string{ls.Path}, nil
+	}
+
+	infos, err := ioutil.ReadDir(ls.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for _, info := range infos {
+		if info.Mode().IsRegular() && !strings.HasPrefix(info.Name(), ".") && !isSignature(info.Name()) {
+			files = append(files, filepath.Join(ls.Path, info.Name()))
+		}
+	}
+
+	return files, nil
+}
+
+// Dir - Will return directory holding source files. Glob patterns may only
+// have wildcards in their last element.
+func (ls ListSource) Dir() string {
+	if !ls.IsGlob() {
+		if fi, err := os.Stat(ls.Path); err == nil && fi.IsDir() {
+			return filepath.Clean(ls.Path)
+		}
+	}
+
+	return filepath.Dir(ls.Path)
+}
+
+// Contains - Will check if provided file is (or would be) part of the source.
+// Signatures of source files are part of the source too.
+func (ls ListSource) Contains(file string) bool {
+	if ls.IsRemote() {
+		return false
+	}
+
+	file = strings.TrimSuffix(filepath.Clean(file), SignatureExtension)
+
+	if ls.IsGlob() {
+		ok, _ := filepath.Match(filepath.Clean(ls.Path), file)
+		return ok
+	}
+
+	if file == filepath.Clean(ls.Path) {
+		return true
+	}
+
+	return ls.Dir() == filepath.Clean(ls.Path) && filepath.Dir(file) == ls.Dir() && !strings.HasPrefix(filepath.Base(file), ".")
+}
+
+// isSignature - Will check if provided file is a detached signature
+func isSignature(file string) bool {
+	return strings.HasSuffix(file, SignatureExtension)
+}
+
+// ParseListSources - Will parse comma separated list of sources. Each source
+// is either "name=path" or just a path (or url) in which case path is its name
+// too.
+func ParseListSources(spec string) []ListSource {
+	sources := []ListSource{}
+
+	for _, part := range strings.Split(spec, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+
+		source := ListSource{Name: part, Path: part}
+
+		if eq := strings.Index(part, "="); eq > 0 && !strings.ContainsAny(part[:eq], "/:") {
+			source.Name = strings.TrimSpace(part[:eq])
+			source.Path = strings.TrimSpace(part[eq+1:])
+		}
+
+		sources = append(sources, source)
+	}
+
+	return sources
+}
@@ -0,0 +1,75 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestListSources(t *testing.T) {
+	Convey("Sources are parsed out of comma separated spec", t, func() {
+		sources := ParseListSources("burner=services/burner/emails.txt, /etc/disposable/*.txt ,,internal = lists/,https://example.com/list?format=txt")
+
+		So(sources, ShouldResemble, []ListSource{
+			{Name: "burner", Path: "services/burner/emails.txt"},
+			{Name: "/etc/disposable/*.txt", Path: "/etc/disposable/*.txt"},
+			{Name: "internal", Path: "lists/"},
+			{Name: "https://example.com/list?format=txt", Path: "https://example.com/list?format=txt"},
+		})
+
+		So(sources[3].IsRemote(), ShouldBeTrue)
+		So(sources[3].IsGlob(), ShouldBeFalse)
+		So(sources[1].IsRemote(), ShouldBeFalse)
+	})
+
+	Convey("Directory sources are made of their visible files", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(os.Mkdir(filepath.Join(dir, "nested"), 0755), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "b.txt"), nil, 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "a.txt"), nil, 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, ".a.txt.swp"), nil, 0644), ShouldBeNil)
+
+		source := ListSource{Name: "internal", Path: dir}
+
+		files, err := source.Files()
+		So(err, ShouldBeNil)
+		So(files, ShouldResemble, []string{filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")})
+
+		So(source.Dir(), ShouldEqual, dir)
+		So(source.Contains(filepath.Join(dir, "c.txt")), ShouldBeTrue)
+		So(source.Contains(filepath.Join(dir, ".a.txt.swp")), ShouldBeFalse)
+		So(source.Contains(filepath.Join(dir, "nested", "a.txt")), ShouldBeFalse)
+	})
+
+	Convey("Glob sources are made of matching files", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		So(ioutil.WriteFile(filepath.Join(dir, "a.txt"), nil, 0644), ShouldBeNil)
+		So(ioutil.WriteFile(filepath.Join(dir, "a.csv"), nil, 0644), ShouldBeNil)
+
+		source := ListSource{Name: "internal", Path: filepath.Join(dir, "*.txt")}
+
+		files, err := source.Files()
+		So(err, ShouldBeNil)
+		So(files, ShouldResemble, []string{filepath.Join(dir, "a.txt")})
+
+		So(source.Dir(), ShouldEqual, dir)
+		So(source.Contains(filepath.Join(dir, "b.txt")), ShouldBeTrue)
+		So(source.Contains(filepath.Join(dir, "b.csv")), ShouldBeFalse)
+
+		_, err = ListSource{Name: "none", Path: filepath.Join(dir, "*.json")}.Files()
+		So(err, ShouldNotBeNil)
+	})
+}
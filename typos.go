@@ -0,0 +1,262 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// DefaultSuggestDomains - Popular provider domains mistyped domains are
+// corrected to, most popular first
+const DefaultSuggestDomains = "gmail.com,yahoo.com,hotmail.com,outlook.com,icloud.com,aol.com,mail.com,live.com,msn.com,me.com,googlemail.com,ymail.com,protonmail.com,gmx.com,gmx.de,web.de,yandex.ru,mail.ru,comcast.net,verizon.net,att.net,hotmail.co.uk,yahoo.co.uk"
+
+// DefaultSuggestTLDs - Top level domains (public suffixes) mistyped ones are
+// corrected to. Only suffixes public suffix list does not know are mistyped.
+const DefaultSuggestTLDs = "com,net,org,edu,gov,io,co,info,biz,me,us,uk,co.uk,ca,de,fr,it,es,nl,ru,au,com.au,jp,in,br,com.br"
+
+const (
+	// MaxSuggestDistance - Domains further away than this from every popular
+	// domain are not corrected
+	MaxSuggestDistance = 1.0
+
+	// MinSuggestLabelLength - Popular domains with shorter first label are
+	// never suggested as too many real domains are a typo away from them
+	MinSuggestLabelLength = 5
+
+	// MaxSuggestTLDDistance - Unknown top level domains further away than
+	// this from every known one are not corrected
+	MaxSuggestTLDDistance = 1.0
+
+	// adjacentKeyCost - Cost of substituting a character with one on a
+	// neighbouring key, typing mistakes are a lot more likely there
+	adjacentKeyCost = 0.5
+)
+
+// keyboardRows - QWERTY rows, each one shifted half a key to the right of the
+// one above it
+var keyboardRows = []string{"1234567890-", "qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// keyPositions - Row and column of every key on keyboardRows
+var keyPositions = func() map[byte][2]int {
+	positions := map[byte][2]int{}
+
+	for row, keys := range keyboardRows {
+		for col := 0; col < len(keys); col++ {
+			positions[keys[col]] = [2]int{row, col}
+		}
+	}
+
+	return positions
+}()
+
+// Suggestion - Domain a mistyped domain was most likely meant to be
+type Suggestion struct {
+	Domain string
+
+	// Confident - Mistyped domain can hardly be a real one, its top level
+	// domain does not exist or it is a single swap of neighbouring
+	// characters or neighbouring key hit away from a popular domain
+	Confident bool
+}
+
+// Suggester - Will suggest popular provider domain or top level domain a
+// domain was most likely mistyped from
+type Suggester struct {
+	domains []string
+	known   map[string]bool
+	tlds    []string
+}
+
+// NewSuggester - Will return suggester correcting domains to provided popular
+// domains and top level domains
+func NewSuggester(domains, tlds []string) *Suggester {
+	s := &Suggester{known: map[string]bool{}}
+
+	for _, domain := range domains {
+		if domain = NormalizeDomain(domain); domain != "" && !s.known[domain] {
+			s.domains = append(s.domains, domain)
+			s.known[domain] = true
+		}
+	}
+
+	for _, tld := range tlds {
+		if tld = NormalizeDomain(tld); tld != "" {
+			s.tlds = append(s.tlds, tld)
+		}
+	}
+
+	return s
+}
+
+// NewSuggesterFromEnv - Will return suggester configured through environment
+func NewSuggesterFromEnv() *Suggester {
+	s := NewSuggester(
+		strings.Split(OptionString("SUGGEST_DOMAINS", DefaultSuggestDomains), ","),
+		strings.Split(OptionString("SUGGEST_TLDS", DefaultSuggestTLDs), ","),
+	)
+
+	log.Infof("[suggester] Suggesting (domains: %d) and (tlds: %d)", len(s.domains), len(s.tlds))
+	return s
+}
+
+// Suggest - Will return domain provided one was most likely mistyped from.
+// Closest popular domain is suggested first, closest top level domain next.
+// Popular domains are only suggested for domains starting with the same
+// character no further than MaxSuggestDistance away, top level domains only
+// when provided public suffix list does not know them. Popular domains,
+// public suffixes and domains that are not close to any popular domain are
+// left alone. Nil suggester suggests nothing.
+func (s *Suggester) Suggest(domain string, suffixes *PublicSuffixList) (*Suggestion, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	if domain = NormalizeDomain(domain); domain == "" || s.known[domain] || suffixes.Known(domain) {
+		return nil, false
+	}
+
+	var best *Suggestion
+	bestDistance := MaxSuggestDistance
+
+	for _, candidate := range s.domains {
+		if strings.Index(candidate+".", ".") < MinSuggestLabelLength || candidate[0] != domain[0] {
+			continue
+		}
+
+		if d := TypoDistance(domain, candidate); d <= bestDistance && (best == nil || d < bestDistance) {
+			best, bestDistance = &Suggestion{Domain: candidate, Confident: d < 1 || transposed(domain, candidate)}, d
+		}
+	}
+
+	if best != nil {
+		return best, true
+	}
+
+	return s.suggestTLD(domain, suffixes)
+}
+
+// suggestTLD - Will replace top level domain of provided domain public suffix
+// list does not know with the closest known one. Without public suffix list
+// real top level domains can't be told apart from mistyped ones so nothing is
+// replaced.
+func (s *Suggester) suggestTLD(domain string, suffixes *PublicSuffixList) (*Suggestion, bool) {
+	labels := strings.Split(domain, ".")
+	if suffixes.Len() == 0 || len(labels) < 2 || suffixes.Known(labels[len(labels)-1]) {
+		return nil, false
+	}
+
+	best, bestDistance, bestLabels := "", MaxSuggestTLDDistance, 0
+
+	for _, tld := range s.tlds {
+		n := strings.Count(tld, ".") + 1
+		if n >= len(labels) || !suffixes.Known(tld) {
+			continue
+		}
+
+		if d := TypoDistance(strings.Join(labels[len(labels)-n:], "."), tld); d <= bestDistance && (best == "" || d < bestDistance) {
+			best, bestDistance, bestLabels = tld, d, n
+		}
+	}
+
+	if best == "" {
+		return nil, false
+	}
+
+	return &Suggestion{Domain: strings.Join(labels[:len(labels)-bestLabels], ".") + "." + best, Confident: true}, true
+}
+
+// transposed - Will check if two strings differ only by a single swap of
+// neighbouring characters
+func transposed(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := 0; i < len(a)-1; i++ {
+		if a[i] != b[i] {
+			return a[i] == b[i+1] && a[i+1] == b[i] && a[i+2:] == b[i+2:]
+		}
+	}
+
+	return false
+}
+
+// TypoDistance - Will return Damerau-Levenshtein (optimal string alignment)
+// distance between two strings where substituting a character with one on
+// a neighbouring key costs only half as much
+func TypoDistance(a, b string) float64 {
+	prev2 := make([]float64, len(b)+1)
+	prev := make([]float64, len(b)+1)
+	cur := make([]float64, len(b)+1)
+
+	for j := range prev {
+		prev[j] = float64(j)
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = float64(i)
+
+		for j := 1; j <= len(b); j++ {
+			cost := substitutionCost(a[i-1], b[j-1])
+			cur[j] = minFloat(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && a[i-1] != b[j-1] {
+				cur[j] = minFloat(cur[j], prev2[j-2]+1)
+			}
+		}
+
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	return prev[len(b)]
+}
+
+// substitutionCost - Will return cost of typing one character instead of
+// another
+func substitutionCost(a, b byte) float64 {
+	switch {
+	case a == b:
+		return 0
+	case adjacentKeys(a, b):
+		return adjacentKeyCost
+	}
+
+	return 1
+}
+
+// adjacentKeys - Will check if two keys neighbour each other on keyboard
+func adjacentKeys(a, b byte) bool {
+	pa, oka := keyPositions[a]
+	pb, okb := keyPositions[b]
+	if !oka || !okb {
+		return false
+	}
+
+	switch pb[0] - pa[0] {
+	case 0:
+		return pb[1]-pa[1] == 1 || pa[1]-pb[1] == 1
+	case -1:
+		return pb[1] == pa[1] || pb[1] == pa[1]+1
+	case 1:
+		return pb[1] == pa[1] || pb[1] == pa[1]-1
+	}
+
+	return false
+}
+
+// minFloat - Will return the smallest of provided values
+func minFloat(values ...float64) float64 {
+	min := values[0]
+
+	for _, v := range values[1:] {
+		if v < min {
+			min = v
+		}
+	}
+
+	return min
+}
@@ -0,0 +1,121 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// testSuggestSuffixes - Public suffix list subset suggestions are tested with
+const testSuggestSuffixes = `
+com
+net
+org
+edu
+io
+co
+de
+it
+nl
+ru
+ca
+ch
+se
+cz
+at
+eu
+pl
+edu.pl
+uk
+co.uk
+`
+
+func TestSuggester(t *testing.T) {
+	suggester := NewSuggester(strings.Split(DefaultSuggestDomains, ","), strings.Split(DefaultSuggestTLDs, ","))
+	suffixes, _ := ParsePublicSuffixList(strings.NewReader(testSuggestSuffixes))
+
+	Convey("Typo distance favours neighbouring keys and transpositions", t, func() {
+		So(TypoDistance("gmail.com", "gmail.com"), ShouldEqual, 0)
+		So(TypoDistance("gmial.com", "gmail.com"), ShouldEqual, 1)
+		So(TypoDistance("hotmal.com", "hotmail.com"), ShouldEqual, 1)
+		So(TypoDistance("yahoo.con", "yahoo.com"), ShouldEqual, 0.5)
+		So(TypoDistance("yahoo.cpm", "yahoo.com"), ShouldEqual, 0.5)
+		So(TypoDistance("yahoo.cqm", "yahoo.com"), ShouldEqual, 1)
+		So(TypoDistance("", "com"), ShouldEqual, 3)
+
+		So(transposed("gmial", "gmail"), ShouldBeTrue)
+		So(transposed("gmail", "gmail"), ShouldBeFalse)
+		So(transposed("gmal", "gmail"), ShouldBeFalse)
+		So(transposed("gmila", "gmail"), ShouldBeFalse)
+
+		So(adjacentKeys('n', 'm'), ShouldBeTrue)
+		So(adjacentKeys('s', 'w'), ShouldBeTrue)
+		So(adjacentKeys('s', 'z'), ShouldBeTrue)
+		So(adjacentKeys('s', 'r'), ShouldBeFalse)
+		So(adjacentKeys('q', '.'), ShouldBeFalse)
+	})
+
+	Convey("Mistyped popular domains are corrected", t, func() {
+		for domain, suggestion := range map[string]Suggestion{
+			"gmial.com":   {Domain: "gmail.com", Confident: true},
+			"GMAL.com":    {Domain: "gmail.com"},
+			"gmail.co":    {Domain: "gmail.com"},
+			"yahoo.con":   {Domain: "yahoo.com", Confident: true},
+			"hotmal.com":  {Domain: "hotmail.com"},
+			"hotmail.cmo": {Domain: "hotmail.com", Confident: true},
+			"outlok.com":  {Domain: "outlook.com"},
+			"icluod.com":  {Domain: "icloud.com", Confident: true},
+		} {
+			suggested, ok := suggester.Suggest(domain, suffixes)
+			So(ok, ShouldBeTrue)
+			So(*suggested, ShouldResemble, suggestion)
+		}
+	})
+
+	Convey("Mistyped top level domains are corrected", t, func() {
+		for domain, suggestion := range map[string]string{
+			"example.con":    "example.com",
+			"example.ner":    "example.net",
+			"mx.example.orh": "mx.example.org",
+			"example.co.uj":  "example.co.uk",
+		} {
+			suggested, ok := suggester.Suggest(domain, suffixes)
+			So(ok, ShouldBeTrue)
+			So(suggested.Domain, ShouldEqual, suggestion)
+			So(suggested.Confident, ShouldBeTrue)
+		}
+	})
+
+	Convey("Real domains a typo away from popular ones are left alone", t, func() {
+		for _, domain := range []string{"email.com", "love.com", "cloud.com", "ymail.com", "mail.com", "msm.com", "aol.co", "co.uk"} {
+			_, ok := suggester.Suggest(domain, suffixes)
+			So(ok, ShouldBeFalse)
+		}
+	})
+
+	Convey("Top level domains public suffix list knows are left alone", t, func() {
+		for _, domain := range []string{"example.ch", "firma.se", "seznam.cz", "orf.at", "europa.eu", "uni.edu.pl", "example.co", "example.co.uk"} {
+			_, ok := suggester.Suggest(domain, suffixes)
+			So(ok, ShouldBeFalse)
+		}
+
+		_, ok := suggester.Suggest("example.con", nil)
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Popular and unrelated domains are left alone", t, func() {
+		for _, domain := range []string{"gmail.com", "mail.com", "aim.com", "example.com", "example.co", "example.co.uk", "company.io", "xn--mller-kva.de", "localhost", ""} {
+			_, ok := suggester.Suggest(domain, suffixes)
+			So(ok, ShouldBeFalse)
+		}
+
+		var nilSuggester *Suggester
+		_, ok := nilSuggester.Suggest("gmial.com", suffixes)
+		So(ok, ShouldBeFalse)
+	})
+}
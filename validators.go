@@ -5,17 +5,46 @@
 package main
 
 import (
+	"strings"
+
 	disposable "github.com/0x19/disposable/protos"
-	"github.com/asaskevich/govalidator"
 )
 
-// ValidateEmail -
-func ValidateEmail(email string) *disposable.DisposableResponse {
-	if !govalidator.IsEmail(email) {
-		return &disposable.DisposableResponse{
+// ValidateEmail - Will parse provided email address making sure it is valid.
+// UTF-8 local parts (SMTPUTF8) and single label domains are accepted only if
+// options allow them. Failed response carries the syntax rule address breaks
+// in error info.
+func ValidateEmail(email string, opts AddressOptions) (*Address, *disposable.DisposableResponse) {
+	address, err := parseAddress(email, opts)
+	if err != nil {
+		resp := &disposable.DisposableResponse{
+			Status:    false,
+			RequestId: GetUUID(),
+			Error:     disposable.NewError(ErrorInvalidEmailAddress, TypeInvalidEmailAddress, err),
+		}
+		resp.Error.Info["rule"] = err.(*AddressError).Rule
+		return nil, resp
+	}
+	return address, nil
+}
+
+// EmailSyntaxRule - Will return syntax rule provided email address breaks or
+// empty string if address is valid.
+func EmailSyntaxRule(email string) string {
+	if _, err := ParseAddress(email); err != nil {
+		return err.(*AddressError).Rule
+	}
+
+	return ""
+}
+
+// ValidateDomain - Will make sure provided domain is a valid host name
+func ValidateDomain(domain string) *disposable.DisposableDomainResponse {
+	if domain = NormalizeDomain(domain); DomainSyntaxRule(domain) != "" || strings.HasPrefix(domain, "[") {
+		return &disposable.DisposableDomainResponse{
 			Status:    false,
 			RequestId: GetUUID(),
-			Error:     disposable.NewError(ErrorInvalidEmailAddress, TypeInvalidEmailAddress, nil),
+			Error:     disposable.NewError(ErrorInvalidDomain, TypeInvalidDomain, nil),
 		}
 	}
 	return nil
@@ -0,0 +1,93 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"path/filepath"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/net/context"
+)
+
+// WatchDebounce - Bursts of file events closer than this trigger single reload
+// so editors and tools replacing files through temporary ones don't cause
+// reload on every step.
+const WatchDebounce = 1 * time.Second
+
+// Watch - Will watch list sources for changes and reload the list whenever
+// any of their files is written, (re)created or removed. Directories are
+// watched instead of files so that atomic replacements and files added to
+// directory or glob sources are picked up too. Blocks until context is done.
+func (de *DisposableEmails) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	sources := []ListSource{}
+	dirs := map[string]bool{}
+
+	all := append(append([]ListSource{}, de.Sources...), de.AllowSources...)
+
+	for _, source := range append(all, ListSource{Name: "suffixes", Path: de.SuffixesSource}) {
+		if source.Path == "" || source.IsRemote() {
+			continue
+		}
+
+		abs, err := filepath.Abs(source.Path)
+		if err != nil {
+			return err
+		}
+
+		source.Path = abs
+		sources = append(sources, source)
+		dirs[source.Dir()] = true
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			log.Warnf("[watch] Could not watch (dir: %s) for changes due to (err: %s)", dir, err)
+			continue
+		}
+
+		log.Infof("[watch] Watching (dir: %s) for list changes...", dir)
+	}
+
+	var pending <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-watcher.Events:
+			if watched(sources, event.Name) && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 {
+				log.Infof("[watch] Noticed (event: %s). Scheduling reload...", event)
+				pending = time.After(WatchDebounce)
+			}
+		case err := <-watcher.Errors:
+			log.Errorf("[watch] Watcher failed due to (err: %s)", err)
+		case <-pending:
+			pending = nil
+
+			if err := de.Load(); err != nil {
+				log.Errorf("[watch] Failed to reload list due to (err: %s). Previous list keeps serving.", err)
+			}
+		}
+	}
+}
+
+// watched - Will check if provided file belongs to any of the sources
+func watched(sources []ListSource, file string) bool {
+	for _, source := range sources {
+		if source.Contains(file) {
+			return true
+		}
+	}
+
+	return false
+}
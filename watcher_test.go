@@ -0,0 +1,47 @@
+// Copyright 2016 Nevio Vesic
+// Please check out LICENSE file for more information about limitations
+// MIT License
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+)
+
+func TestWatch(t *testing.T) {
+	Convey("List is reloaded when source file changes", t, func() {
+		dir, err := ioutil.TempDir("", "disposable_")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		source := filepath.Join(dir, "emails.txt")
+		So(ioutil.WriteFile(source, []byte("mailinator.com\n"), 0644), ShouldBeNil)
+
+		emails := &DisposableEmails{Sources: []ListSource{{Name: "burner", Path: source}}}
+		So(emails.Load(), ShouldBeNil)
+		So(emails.Len(), ShouldEqual, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go emails.Watch(ctx)
+		time.Sleep(100 * time.Millisecond)
+
+		So(ioutil.WriteFile(source, []byte("mailinator.com\nguerrillamail.com\n"), 0644), ShouldBeNil)
+
+		deadline := time.Now().Add(WatchDebounce + 3*time.Second)
+		for emails.Len() != 2 && time.Now().Before(deadline) {
+			time.Sleep(50 * time.Millisecond)
+		}
+
+		So(emails.Len(), ShouldEqual, 2)
+		So(emails.DomainExists("guerrillamail.com"), ShouldBeTrue)
+	})
+}